@@ -0,0 +1,57 @@
+package sgp4go
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// alpha5Letters are the leading characters of Alpha-5 catalog
+// numbers, which start at 10 (for 'A').  'I' and 'O' are not used.
+const alpha5Letters = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+
+// ParseAlpha5 parses a five-column catalog number, which can use the
+// Alpha-5 scheme for numbers from 100000 to 339999.  In that scheme,
+// the first character is a letter that represents the number's
+// leading digits ("A0001" is 100001, and "Z9999" is 339999).
+//
+// Leading and trailing blanks are ignored, but an Alpha-5 number
+// must be exactly a letter and four digits.
+func ParseAlpha5(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, errBlank
+	}
+
+	if c := s[0]; 'A' <= c && c <= 'Z' {
+		i := strings.IndexByte(alpha5Letters, c)
+		if i < 0 || len(s) != 5 {
+			return 0, fmt.Errorf("bad Alpha-5 catalog number %q", s)
+		}
+		// ParseUint (unlike checkDigits) rejects blanks.
+		n, err := strconv.ParseUint(s[1:], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("bad Alpha-5 catalog number %q", s)
+		}
+		return int64(10+i)*10000 + int64(n), nil
+	}
+
+	if err := checkDigits(s); err != nil {
+		return 0, fmt.Errorf("bad catalog number %q", s)
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
+
+// FormatAlpha5 renders a catalog number in five columns, using the
+// Alpha-5 scheme for numbers from 100000 to 339999.
+//
+// See ParseAlpha5().
+func FormatAlpha5(n int64) (string, error) {
+	switch {
+	case 0 <= n && n <= 99999:
+		return fmt.Sprintf("%05d", n), nil
+	case 100000 <= n && n <= 339999:
+		return fmt.Sprintf("%c%04d", alpha5Letters[n/10000-10], n%10000), nil
+	}
+	return "", fmt.Errorf("catalog number %d out of range", n)
+}
@@ -0,0 +1,79 @@
+package sgp4go
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestAlpha5(t *testing.T) {
+	for _, tc := range []struct {
+		s string
+		n int64
+	}{
+		{"00005", 5},
+		{"    5", 5},
+		{"99999", 99999},
+		{"A0000", 100000},
+		{"A0001", 100001},
+		{"H9999", 179999},
+		{"J0000", 180000},
+		{"P0000", 230000},
+		{"Z9999", 339999},
+	} {
+		n, err := ParseAlpha5(tc.s)
+		if err != nil {
+			t.Fatal(tc.s, err)
+		}
+		if n != tc.n {
+			t.Fatal(tc.s, n)
+		}
+		s, err := FormatAlpha5(n)
+		if err != nil {
+			t.Fatal(n, err)
+		}
+		if s != strings.Replace(tc.s, "    5", "00005", 1) {
+			t.Fatal(n, s)
+		}
+	}
+
+	for _, s := range []string{"", "I0000", "O1234", "a0001", "A001", "A 001", "A00 1", "A+001", "A00x1", "-0001", "1 234"} {
+		if n, err := ParseAlpha5(s); err == nil {
+			t.Fatal(s, n)
+		}
+	}
+
+	for _, n := range []int64{-1, 340000} {
+		if s, err := FormatAlpha5(n); err == nil {
+			t.Fatal(n, s)
+		}
+	}
+}
+
+func TestAlpha5TLE(t *testing.T) {
+	var (
+		lines = strings.Split(ISS, "\n")
+		fix   = func(line string) string {
+			line = line[:2] + "T1234" + line[7:68]
+			return line + fmt.Sprint(Checksum(line))
+		}
+		line1 = fix(lines[1])
+		line2 = fix(lines[2])
+	)
+
+	o, err := NewTLE(line1, line2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := o.NoradCatNum(); n != 271234 {
+		t.Fatal(n)
+	}
+
+	got1, got2, err := o.Format()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got1 != line1 || got2 != line2 {
+		t.Fatalf("\n%s\n%s", got1, got2)
+	}
+}
@@ -0,0 +1,136 @@
+package sgp4go
+
+import (
+	"fmt"
+	"time"
+)
+
+// Ephemerides is a series of states stored as a structure of arrays,
+// which batch propagation (PropTimes() and PropRange()) fills.
+//
+// Units and coordinates are those of Ephemeris.
+type Ephemerides struct {
+	Times []time.Time
+
+	// X, Y, and Z are position in km.
+	X, Y, Z []float64
+
+	// VX, VY, and VZ are velocity in km/sec.
+	VX, VY, VZ []float64
+}
+
+// NewEphemerides allocates Ephemerides with room for n states.
+func NewEphemerides(n int) *Ephemerides {
+	return &Ephemerides{
+		Times: make([]time.Time, n),
+		X:     make([]float64, n),
+		Y:     make([]float64, n),
+		Z:     make([]float64, n),
+		VX:    make([]float64, n),
+		VY:    make([]float64, n),
+		VZ:    make([]float64, n),
+	}
+}
+
+// Len returns the number of states that fit in all of the slices.
+func (e *Ephemerides) Len() int {
+	n := len(e.Times)
+	for _, xs := range [][]float64{e.X, e.Y, e.Z, e.VX, e.VY, e.VZ} {
+		if len(xs) < n {
+			n = len(xs)
+		}
+	}
+	return n
+}
+
+// At returns the i-th state.
+func (e *Ephemerides) At(i int) Ephemeris {
+	return Ephemeris{
+		V:   Vect{e.VX[i], e.VY[i], e.VZ[i]},
+		ECI: Vect{e.X[i], e.Y[i], e.Z[i]},
+	}
+}
+
+// Steps returns the number of times from start (inclusive) to stop
+// (exclusive) at the given interval, which is the number of states
+// PropRange() produces.
+func Steps(start, stop time.Time, step time.Duration) int {
+	d := stop.Sub(start)
+	if step <= 0 || d <= 0 {
+		return 0
+	}
+	return int((d-1)/step) + 1
+}
+
+// BatchError reports the first failure during batch propagation.
+type BatchError struct {
+	// Index is the index of the time that could not be propagated.
+	Index int
+
+	// Err is the propagation error.
+	Err error
+}
+
+// Error makes BatchError an error.
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("state %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// propIndex propagates to the given minutes after epoch and stores
+// the results at the given index.
+func (o *TLE) propIndex(mins float64, t time.Time, out *Ephemerides, i int) error {
+	var r, v [3]float64
+	if code := propagate(o, mins, &r[0], &v[0]); code != 0 {
+		return &BatchError{
+			Index: i,
+			Err:   o.propError(code, t, mins),
+		}
+	}
+	out.Times[i] = t
+	out.X[i], out.Y[i], out.Z[i] = r[0], r[1], r[2]
+	out.VX[i], out.VY[i], out.VZ[i] = v[0], v[1], v[2]
+	return nil
+}
+
+// PropTimes propagates to each of the given times, writing the states
+// to out, which must have room for them (see NewEphemerides()).
+//
+// The number of states written is returned.  If propagation fails,
+// the states before the failure are kept, and the error is a
+// *BatchError with the index of the failed time.
+func (o *TLE) PropTimes(ts []time.Time, out *Ephemerides) (int, error) {
+	if n := out.Len(); n < len(ts) {
+		return 0, fmt.Errorf("room for %d states but %d times", n, len(ts))
+	}
+	for i, t := range ts {
+		if err := o.propIndex(minutesSinceEpoch(&o.Rec, t), t, out, i); err != nil {
+			return i, err
+		}
+	}
+	return len(ts), nil
+}
+
+// PropRange propagates from start (inclusive) to stop (exclusive) at
+// the given interval, writing the states to out.
+//
+// Propagation also stops when out is full, and Steps() gives the
+// required room.  Otherwise PropRange is like PropTimes().
+func (o *TLE) PropRange(start, stop time.Time, step time.Duration, out *Ephemerides) (int, error) {
+	n := Steps(start, stop, step)
+	if m := out.Len(); m < n {
+		n = m
+	}
+	mins := minutesSinceEpoch(&o.Rec, start)
+	for i := 0; i < n; i++ {
+		d := time.Duration(i) * step
+		if err := o.propIndex(mins+d.Minutes(), start.Add(d), out, i); err != nil {
+			return i, err
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,147 @@
+package sgp4go
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSteps(t *testing.T) {
+	var (
+		t0 = time.Date(2020, 12, 14, 0, 0, 0, 0, time.UTC)
+		m  = time.Minute
+	)
+	for _, tc := range []struct {
+		stop time.Time
+		step time.Duration
+		n    int
+	}{
+		{t0, m, 0},
+		{t0.Add(-m), m, 0},
+		{t0.Add(m), 0, 0},
+		{t0.Add(m), m, 1},
+		{t0.Add(m + 1), m, 2},
+		{t0.Add(10 * m), m, 10},
+		{t0.Add(10 * m), 3 * m, 4},
+	} {
+		if n := Steps(t0, tc.stop, tc.step); n != tc.n {
+			t.Fatal(tc, n)
+		}
+	}
+}
+
+func TestPropRange(t *testing.T) {
+	var (
+		o     = getExample(t)
+		start = o.Epoch()
+		stop  = start.Add(time.Hour)
+		step  = 7 * time.Second
+		out   = NewEphemerides(Steps(start, stop, step))
+	)
+
+	n, err := o.PropRange(start, stop, step, out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != out.Len() || !out.Times[n-1].Before(stop) {
+		t.Fatal(n)
+	}
+
+	for i := 0; i < n; i++ {
+		ti := start.Add(time.Duration(i) * step)
+		if !out.Times[i].Equal(ti) {
+			t.Fatal(i, out.Times[i])
+		}
+		e, err := o.Prop(ti)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := out.At(i); 1e-8 < math.Abs(got.ECI.X-e.ECI.X) || 1e-11 < math.Abs(got.V.Z-e.V.Z) {
+			t.Fatal(i, got, e)
+		}
+	}
+
+	t.Run("full", func(t *testing.T) {
+		short := NewEphemerides(10)
+		if n, err := o.PropRange(start, stop, step, short); err != nil || n != 10 {
+			t.Fatal(n, err)
+		}
+	})
+
+	t.Run("times", func(t *testing.T) {
+		ts := []time.Time{stop, start, start.Add(step)}
+		n, err := o.PropTimes(ts, out)
+		if err != nil || n != len(ts) {
+			t.Fatal(n, err)
+		}
+		if got, want := out.At(0), out.At(2); got == want {
+			t.Fatal(got)
+		}
+		if n, err := o.PropTimes(ts, NewEphemerides(2)); err == nil {
+			t.Fatal(n)
+		}
+	})
+}
+
+func TestPropRangeDecay(t *testing.T) {
+	var (
+		o     = getDecaying(t)
+		start = o.Epoch()
+		stop  = start.Add(24 * time.Hour)
+		step  = 20 * time.Minute
+		out   = NewEphemerides(Steps(start, stop, step))
+	)
+
+	n, err := o.PropRange(start, stop, step, out)
+	if err == nil {
+		t.Fatal(n)
+	}
+	var be *BatchError
+	if !errors.As(err, &be) || be.Index != n || !HasDecayed(err) {
+		t.Fatal(err)
+	}
+	if n < 21 || 22 < n {
+		t.Fatal(n)
+	}
+	if out.Times[n-1].IsZero() || !out.Times[n].IsZero() {
+		t.Fatal(out.Times[n-1:])
+	}
+}
+
+func TestPropRangeAllocs(t *testing.T) {
+	var (
+		o     = getExample(t)
+		start = o.Epoch()
+		stop  = start.Add(time.Hour)
+		out   = NewEphemerides(Steps(start, stop, time.Minute))
+	)
+
+	allocs := testing.AllocsPerRun(10, func() {
+		if _, err := o.PropRange(start, stop, time.Minute, out); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Fatal(allocs)
+	}
+}
+
+func BenchmarkPropRange(b *testing.B) {
+	var (
+		o     = getExample(b)
+		start = o.Epoch()
+		stop  = start.Add(24 * time.Hour)
+		step  = 10 * time.Second
+		out   = NewEphemerides(Steps(start, stop, step))
+	)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := o.PropRange(start, stop, step, out); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package sgp4go
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// Catalog propagates many TLEs concurrently.
+type Catalog struct {
+	// TLEs are the catalog's objects.
+	TLEs []*TLE
+
+	// Workers is the number of goroutines that propagate, which
+	// defaults to GOMAXPROCS.
+	Workers int
+}
+
+// CatalogState is the result of propagating one of a Catalog's TLEs
+// to one time.
+type CatalogState struct {
+	// Index is the index of the TLE in the Catalog.
+	Index int
+
+	TLE  *TLE
+	Time time.Time
+
+	// Ephemeris is the state, which is only valid if Err is nil.
+	Ephemeris Ephemeris
+
+	// Err is the propagation error (if any).  See HasDecayed().
+	Err error
+}
+
+func (c *Catalog) workers() int {
+	if c.Workers <= 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return c.Workers
+}
+
+// Prop propagates every TLE to each of the given times and calls f
+// with each result.
+//
+// Each TLE is handled by a single worker, which propagates it to the
+// times in order.  The workers call f concurrently, so f must be safe
+// for concurrent use.
+//
+// Propagation errors are reported to f and don't stop the batch.  If
+// the context is canceled, Prop stops early and returns the context's
+// error.
+func (c *Catalog) Prop(ctx context.Context, ts []time.Time, f func(CatalogState)) error {
+	var (
+		jobs = make(chan int)
+		wg   sync.WaitGroup
+	)
+
+	for w := 0; w < c.workers(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				o := c.TLEs[i]
+				for _, t := range ts {
+					if ctx.Err() != nil {
+						return
+					}
+					s := CatalogState{
+						Index: i,
+						TLE:   o,
+						Time:  t,
+					}
+					s.Err = o.PropInto(t, &s.Ephemeris)
+					f(s)
+				}
+			}
+		}()
+	}
+
+feed:
+	for i := range c.TLEs {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return ctx.Err()
+}
+
+// CatalogStream is the output of Catalog.Stream().
+type CatalogStream struct {
+	// C receives the results.  It's closed when propagation is done
+	// or the context is canceled.
+	C <-chan CatalogState
+
+	err error
+}
+
+// Err returns the error from Prop() (which is the context's error if
+// the stream was canceled) once C is closed.
+//
+// Err must not be called before C is closed.
+func (s *CatalogStream) Err() error {
+	return s.err
+}
+
+// Stream is Prop() that sends the results to the returned stream.
+func (c *Catalog) Stream(ctx context.Context, ts []time.Time) *CatalogStream {
+	var (
+		ch = make(chan CatalogState, c.workers())
+		s  = &CatalogStream{C: ch}
+	)
+	go func() {
+		defer close(ch)
+		s.err = c.Prop(ctx, ts, func(s CatalogState) {
+			select {
+			case ch <- s:
+			case <-ctx.Done():
+			}
+		})
+	}()
+	return s
+}
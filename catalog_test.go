@@ -0,0 +1,158 @@
+package sgp4go
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// verCatalog returns a Catalog with the (parseable) TLEs in
+// SGP4-VER.TLE.
+func verCatalog(t *testing.T) *Catalog {
+	c := &Catalog{Workers: 4}
+	for _, pair := range verLines(t) {
+		o, err := NewTLE(pair[0], pair[1], WithChecksum(ChecksumIgnore))
+		if err != nil {
+			continue
+		}
+		c.TLEs = append(c.TLEs, o)
+	}
+	if len(c.TLEs) < 20 {
+		t.Fatal(len(c.TLEs))
+	}
+	return c
+}
+
+func TestCatalog(t *testing.T) {
+	var (
+		c  = verCatalog(t)
+		t0 = time.Date(2006, 6, 20, 0, 0, 0, 0, time.UTC)
+		ts = []time.Time{t0, t0.Add(time.Hour), t0.Add(10 * 24 * time.Hour)}
+
+		mu      sync.Mutex
+		n       int
+		decayed = make(map[int64]bool)
+	)
+
+	err := c.Prop(context.Background(), ts, func(s CatalogState) {
+		mu.Lock()
+		defer mu.Unlock()
+		n++
+
+		want, err := s.TLE.Prop(s.Time)
+		if (err == nil) != (s.Err == nil) {
+			t.Error(s.Index, err, s.Err)
+			return
+		}
+		if HasDecayed(s.Err) {
+			decayed[s.TLE.ObjectNum()] = true
+		}
+		if s.Err == nil && s.Ephemeris != want {
+			t.Error(s.Index, s.Ephemeris, want)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n != len(c.TLEs)*len(ts) {
+		t.Fatal(n)
+	}
+	// This object decays within hours of its 2006-06-19 epoch (and its
+	// elements are invalid days later).
+	if !decayed[29141] {
+		t.Fatal(decayed)
+	}
+}
+
+func TestCatalogCancel(t *testing.T) {
+	var (
+		c           = verCatalog(t)
+		ts          = []time.Time{time.Date(2006, 7, 1, 0, 0, 0, 0, time.UTC)}
+		ctx, cancel = context.WithCancel(context.Background())
+		n           int
+	)
+	defer cancel()
+
+	c.Workers = 1
+	err := c.Prop(ctx, ts, func(s CatalogState) {
+		n++
+		cancel()
+	})
+	if err != context.Canceled {
+		t.Fatal(err)
+	}
+	if n == len(c.TLEs) {
+		t.Fatal(n)
+	}
+}
+
+func TestCatalogStream(t *testing.T) {
+	var (
+		c  = verCatalog(t)
+		ts = []time.Time{time.Date(2006, 7, 1, 0, 0, 0, 0, time.UTC)}
+		n  int
+	)
+	st := c.Stream(context.Background(), ts)
+	for s := range st.C {
+		if c.TLEs[s.Index] != s.TLE {
+			t.Fatal(s.Index)
+		}
+		n++
+	}
+	if n != len(c.TLEs) {
+		t.Fatal(n)
+	}
+	if err := st.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A canceled stream is distinguishable from a complete one.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	st = c.Stream(ctx, ts)
+	n = 0
+	for range st.C {
+		if n++; n == 1 {
+			cancel()
+		}
+	}
+	if err := st.Err(); err != context.Canceled {
+		t.Fatal(n, err)
+	}
+}
+
+func BenchmarkCatalog(b *testing.B) {
+	var (
+		o  = getExample(b)
+		c  = &Catalog{TLEs: make([]*TLE, 1000)}
+		ts = []time.Time{o.Epoch().Add(time.Hour)}
+
+		// f runs on the workers, so it records (rather than
+		// reports) an error.
+		mu      sync.Mutex
+		propErr error
+		f       = func(s CatalogState) {
+			if s.Err != nil {
+				mu.Lock()
+				propErr = s.Err
+				mu.Unlock()
+			}
+		}
+	)
+	for i := range c.TLEs {
+		c.TLEs[i] = o
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := c.Prop(context.Background(), ts, f); err != nil {
+			b.Fatal(err)
+		}
+		if propErr != nil {
+			b.Fatal(propErr)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package sgp4go
+
+import (
+	"math"
+	"time"
+)
+
+// Illumination returns the fraction of the Sun's disk that is visible
+// from the position r, where sun is the Sun's position (as from
+// SunPosition()) in the same frame.
+//
+// The shadow is a conical model with a spherical Earth (with the
+// WGS-84 equatorial radius) and no atmosphere.  The result is 1 in
+// sunlight, 0 in the umbra, and in between in the penumbra.
+func Illumination(r, sun Vect) float64 {
+	a, b, c := shadowAngles(r, sun)
+	switch {
+	case a+b <= c:
+		return 1
+	case c <= b-a:
+		return 0
+	case c <= a-b:
+		// Beyond the end of the umbra.
+		return 1 - b*b/(a*a)
+	}
+
+	// The area of the overlap of the disks.
+	var (
+		x = (c*c + a*a - b*b) / (2 * c)
+		y = math.Sqrt(math.Max(0, a*a-x*x))
+		o = a*a*math.Acos(x/a) + b*b*math.Acos((c-x)/b) - c*y
+	)
+	return math.Max(0, math.Min(1, 1-o/(math.Pi*a*a)))
+}
+
+// shadowAngles returns the apparent radii of the Sun (a) and Earth
+// (b) and the angle between their centers (c) seen from r.
+func shadowAngles(r, sun Vect) (a, b, c float64) {
+	var (
+		s  = Vect{sun.X - r.X, sun.Y - r.Y, sun.Z - r.Z}
+		e  = Vect{-r.X, -r.Y, -r.Z}
+		ds = norm(s)
+		de = norm(e)
+	)
+	a = math.Asin(math.Min(1, SunRadius/ds))
+	b = math.Asin(math.Min(1, WGS84Ellipsoid.A/de))
+	c = math.Atan2(norm(cross(s, e)), dot(s, e))
+	return a, b, c
+}
+
+// Illumination returns the fraction of the Sun's disk that is visible
+// from the satellite at the given time.  See Illumination().
+func (o *TLE) Illumination(t time.Time) (float64, error) {
+	var e Ephemeris
+	if err := o.PropInto(t, &e); err != nil {
+		return 0, err
+	}
+	return Illumination(e.ECI, SunPosition(t)), nil
+}
+
+// Eclipse is a passage of a satellite through the Earth's shadow.
+type Eclipse struct {
+	// Penumbra is when the satellite is in the shadow (including
+	// the umbra).
+	Penumbra Interval
+
+	// Umbra lists each interval in the umbra during the Penumbra.
+	// It's empty if the satellite only passes through the penumbra.
+	// There's usually at most one interval, but a satellite that
+	// grazes the umbra can pass in and out more than once.
+	Umbra []Interval
+}
+
+// HasUmbra reports whether the satellite enters the umbra.
+func (e *Eclipse) HasUmbra() bool {
+	return 0 < len(e.Umbra)
+}
+
+// EclipseFinder finds eclipses of satellites.
+//
+// The satellite is propagated in coarse steps, and the times of
+// shadow entry and exit are then found by bisection.  Brief (grazing)
+// eclipses between steps are found by golden-section search near
+// steps where the satellite is locally closest to the shadow, but a
+// step much longer than the shortest eclipses of interest can still
+// miss some.
+type EclipseFinder struct {
+	// Step is the coarse step, which defaults to a minute.
+	Step time.Duration
+
+	// Tolerance is the accuracy of the times, which defaults to a
+	// millisecond.
+	Tolerance time.Duration
+}
+
+func (f *EclipseFinder) step() time.Duration {
+	if f.Step <= 0 {
+		return time.Minute
+	}
+	return f.Step
+}
+
+func (f *EclipseFinder) tolerance() time.Duration {
+	if f.Tolerance <= 0 {
+		return time.Millisecond
+	}
+	return f.Tolerance
+}
+
+// Eclipses returns the eclipses of the TLE from start to stop.
+//
+// An eclipse that is in progress at start (or stop) starts (or stops)
+// at that time.  A propagation error (see HasDecayed()) stops the
+// search, and Eclipses returns the eclipses found before it along
+// with the error.
+func (f *EclipseFinder) Eclipses(tle *TLE, start, stop time.Time) ([]Eclipse, error) {
+	// The margins are angles that are negative in the penumbra (or
+	// umbra) and in the umbra.
+	margin := func(umbra bool) searchFunc {
+		return func(t time.Time) (float64, error) {
+			var e Ephemeris
+			if err := tle.PropInto(t, &e); err != nil {
+				return 0, err
+			}
+			a, b, c := shadowAngles(e.ECI, SunPosition(t))
+			if umbra {
+				return c - (b - a), nil
+			}
+			return c - (a + b), nil
+		}
+	}
+	return f.eclipses(margin(false), margin(true), start, stop)
+}
+
+// eclipses finds the eclipses given the margins from the penumbra and
+// the umbra.
+func (f *EclipseFinder) eclipses(penumbra, umbra searchFunc, start, stop time.Time) ([]Eclipse, error) {
+	var (
+		step     = f.step()
+		tol      = f.tolerance()
+		eclipses []Eclipse
+	)
+
+	pen, err := penumbra.negative(start, stop, step, tol)
+	for _, p := range pen {
+		umb, err := umbra.negative(p.Start, p.Stop, innerStep(p, step, tol), tol)
+		if err != nil {
+			return eclipses, err
+		}
+		eclipses = append(eclipses, Eclipse{Penumbra: p, Umbra: umb})
+	}
+	return eclipses, err
+}
@@ -0,0 +1,203 @@
+package sgp4go
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestIllumination(t *testing.T) {
+	sun := Vect{AU, 0, 0}
+
+	if f := Illumination(Vect{7000, 0, 0}, sun); f != 1 {
+		t.Fatal(f)
+	}
+	if f := Illumination(Vect{-7000, 0, 0}, sun); f != 0 {
+		t.Fatal(f)
+	}
+
+	// Across the edge of the shadow, the illumination increases
+	// continuously.
+	last := 0.0
+	for y := 6300.0; y < 6500; y += 0.1 {
+		f := Illumination(Vect{-7000, y, 0}, sun)
+		if f < last || 0.01 < f-last {
+			t.Fatal(y, f, last)
+		}
+		last = f
+	}
+	if last != 1 {
+		t.Fatal(last)
+	}
+
+	// Beyond the end of the umbra, the Earth covers part of the Sun.
+	var (
+		r = Vect{-2e6, 0, 0}
+		a = math.Asin(SunRadius / (AU + 2e6))
+		b = math.Asin(WGS84Ellipsoid.A / 2e6)
+	)
+	if f := Illumination(r, sun); 1e-12 < math.Abs(f-(1-b*b/(a*a))) {
+		t.Fatal(f)
+	}
+}
+
+func TestEclipses(t *testing.T) {
+	var (
+		tle   = getExample(t)
+		start = time.Date(2021, 1, 3, 7, 0, 0, 0, time.UTC)
+		stop  = start.Add(24 * time.Hour)
+		f     = &EclipseFinder{}
+		ms    = time.Millisecond
+	)
+
+	// Near the TLE's epoch, the Sun is too far from the orbit's plane
+	// (74 degrees) for eclipses.
+	high := time.Date(2020, 12, 14, 7, 0, 0, 0, time.UTC)
+	if eclipses, err := f.Eclipses(tle, high, high.Add(24*time.Hour)); err != nil || len(eclipses) != 0 {
+		t.Fatal(eclipses, err)
+	}
+
+	eclipses, err := f.Eclipses(tle, start, stop)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Count the eclipses by sampling every ten seconds.
+	var (
+		n  int
+		in bool
+	)
+	for at := start; at.Before(stop); at = at.Add(10 * time.Second) {
+		l, err := tle.Illumination(at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !in && l < 1 {
+			n++
+		}
+		in = l < 1
+	}
+	if len(eclipses) != n || n < 15 {
+		t.Fatal(len(eclipses), n)
+	}
+
+	// margins returns the angles from the penumbra and the umbra.
+	margins := func(at time.Time) (float64, float64) {
+		e, err := tle.Prop(at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		a, b, c := shadowAngles(e.ECI, SunPosition(at))
+		return c - (a + b), c - (b - a)
+	}
+
+	for i, e := range eclipses {
+		if i == 0 && e.Penumbra.Start.Equal(start) {
+			continue
+		}
+		if i == len(eclipses)-1 && e.Penumbra.Stop.Equal(stop) {
+			continue
+		}
+		if len(e.Umbra) != 1 {
+			t.Fatal(i, e)
+		}
+		u := e.Umbra[0]
+		if !e.Penumbra.Start.Before(u.Start) ||
+			!u.Start.Before(u.Stop) ||
+			!u.Stop.Before(e.Penumbra.Stop) {
+			t.Fatal(i, e)
+		}
+		// The penumbra takes a few seconds.
+		if d := u.Start.Sub(e.Penumbra.Start); d < 5*time.Second || 20*time.Second < d {
+			t.Fatal(i, e, d)
+		}
+		// The boundaries are good to a millisecond.
+		for _, tc := range []struct {
+			in, out time.Time
+			umbra   bool
+		}{
+			{e.Penumbra.Start, e.Penumbra.Start.Add(-ms), false},
+			{e.Penumbra.Stop, e.Penumbra.Stop.Add(ms), false},
+			{u.Start, u.Start.Add(-ms), true},
+			{u.Stop, u.Stop.Add(ms), true},
+		} {
+			var (
+				pin, uin   = margins(tc.in)
+				pout, uout = margins(tc.out)
+			)
+			if tc.umbra {
+				pin, pout = uin, uout
+			}
+			if 0 <= pin || pout < 0 {
+				t.Fatal(i, e, tc.in, pin, pout)
+			}
+		}
+
+		// Halfway through the penumbra, about half of the Sun is
+		// visible.
+		mid := e.Penumbra.Start.Add(u.Start.Sub(e.Penumbra.Start) / 2)
+		if l, err := tle.Illumination(mid); err != nil || l < 0.4 || 0.6 < l {
+			t.Fatal(i, l, err)
+		}
+		if l, _ := tle.Illumination(u.Start.Add(time.Minute)); l != 0 {
+			t.Fatal(i, l)
+		}
+	}
+}
+
+func TestEclipsesGrazing(t *testing.T) {
+	var (
+		start = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		stop  = start.Add(time.Hour)
+		mid   = start.Add(30 * time.Minute)
+		f     = &EclipseFinder{}
+		ms    = time.Millisecond
+
+		// The penumbra is ten minutes around mid, and the umbra is
+		// two minutes at each end of it, as for a satellite that
+		// grazes the umbra.
+		penumbra = func(at time.Time) (float64, error) {
+			return math.Abs(at.Sub(mid).Seconds()) - 300, nil
+		}
+		umbra = func(at time.Time) (float64, error) {
+			return math.Abs(math.Abs(at.Sub(mid).Seconds())-180) - 60, nil
+		}
+	)
+
+	eclipses, err := f.eclipses(penumbra, umbra, start, stop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(eclipses) != 1 {
+		t.Fatal(eclipses)
+	}
+	e := eclipses[0]
+	want := []Interval{
+		{mid.Add(-4 * time.Minute), mid.Add(-2 * time.Minute)},
+		{mid.Add(2 * time.Minute), mid.Add(4 * time.Minute)},
+	}
+	if !e.HasUmbra() || len(e.Umbra) != len(want) {
+		t.Fatal(e)
+	}
+	for i, u := range e.Umbra {
+		if d := u.Start.Sub(want[i].Start); d < -ms || ms < d {
+			t.Fatal(i, u, want[i])
+		}
+		if d := u.Stop.Sub(want[i].Stop); d < -ms || ms < d {
+			t.Fatal(i, u, want[i])
+		}
+	}
+}
+
+func BenchmarkEclipses(b *testing.B) {
+	var (
+		tle   = getExample(b)
+		start = time.Date(2021, 1, 3, 7, 0, 0, 0, time.UTC)
+		f     = &EclipseFinder{}
+	)
+	for i := 0; i < b.N; i++ {
+		if _, err := f.Eclipses(tle, start, start.Add(24*time.Hour)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -1,5 +1,11 @@
 package sgp4go
 
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
 type Elements struct {
 	// *    em          - eccentricity
 
@@ -37,3 +43,87 @@ func (tle *TLE) Elements() Elements {
 		RightAscension: tle.raanDeg,
 	}
 }
+
+// Metadata is the data in a TLE other than its Elements.
+type Metadata struct {
+	// CatalogNumber is the NORAD catalog number.
+	CatalogNumber int64
+
+	// Classification is "U" (unclassified), "C" (classified), or
+	// "S" (secret).
+	Classification string
+
+	// IntlDesignator is the international designator as given in
+	// the TLE (e.g., "98067A").
+	IntlDesignator string
+
+	// LaunchYear is the four-digit launch year from the
+	// international designator (or zero).
+	LaunchYear int
+
+	// LaunchNumber is the launch number of the year from the
+	// international designator (or zero).
+	LaunchNumber int
+
+	// LaunchPiece is the piece of the launch from the
+	// international designator (e.g., "A").
+	LaunchPiece string
+
+	// Epoch is the epoch of the elements.
+	Epoch time.Time
+
+	// ElementSetNumber is the element set number.
+	ElementSetNumber int64
+
+	// RevNumber is the revolution number at epoch.
+	RevNumber int64
+
+	// MeanMotionDot is the first derivative of mean motion divided
+	// by 2 (rev/day^2).
+	MeanMotionDot float64
+
+	// MeanMotionDDot is the second derivative of mean motion
+	// divided by 6 (rev/day^3).
+	MeanMotionDDot float64
+
+	// BStar is the B* drag term (1/earth radii).
+	BStar float64
+
+	// EphemerisType is the ephemeris type (usually zero).
+	EphemerisType int
+}
+
+// Metadata returns the TLE's data other than its Elements.
+func (tle *TLE) Metadata() Metadata {
+	m := Metadata{
+		CatalogNumber:    tle.objectNum,
+		IntlDesignator:   strings.TrimSpace(strings.Trim(string(tle.intlid[:8]), "\x00")),
+		Epoch:            tle.Epoch(),
+		ElementSetNumber: tle.elnum,
+		RevNumber:        tle.revnum,
+		MeanMotionDot:    tle.ndot,
+		MeanMotionDDot:   tle.nddot,
+		BStar:            tle.bstar,
+		EphemerisType:    int(tle.Rec.ephtype),
+	}
+	if c := tle.Rec.classification; c != 0 && c != ' ' {
+		m.Classification = string(c)
+	}
+
+	// International designators look like "98067A" (maybe
+	// followed by blanks).
+	id := m.IntlDesignator
+	if 5 < len(id) && checkDigits(id[0:5]) == nil {
+		yr, _ := strconv.Atoi(id[0:2])
+		m.LaunchYear = fullYear(int64(yr))
+		m.LaunchNumber, _ = strconv.Atoi(id[2:5])
+		m.LaunchPiece = id[5:]
+	}
+
+	return m
+}
+
+// Epoch returns the epoch of the TLE.
+func (tle *TLE) Epoch() time.Time {
+	return epochTime(tle)
+}
@@ -0,0 +1,42 @@
+package sgp4go
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestMetadata(t *testing.T) {
+	var (
+		o    = getExample(t)
+		got  = o.Metadata()
+		want = Metadata{
+			CatalogNumber:    25544,
+			Classification:   "U",
+			IntlDesignator:   "98067A",
+			LaunchYear:       1998,
+			LaunchNumber:     67,
+			LaunchPiece:      "A",
+			Epoch:            got.Epoch,
+			ElementSetNumber: 999,
+			RevNumber:        25993,
+			MeanMotionDot:    0.00001103,
+			MeanMotionDDot:   0,
+			BStar:            0.27992e-4,
+		}
+	)
+
+	if d := got.Epoch.Sub(time.Date(2020, 12, 14, 6, 45, 49, 70880000, time.UTC)); d < -time.Microsecond || time.Microsecond < d {
+		t.Fatal(got.Epoch)
+	}
+
+	// BStar might be off in the last bit.
+	if math.Abs(got.BStar/want.BStar-1) > 1e-15 {
+		t.Fatal(got.BStar)
+	}
+	want.BStar = got.BStar
+
+	if got != want {
+		t.Fatalf("%#v", got)
+	}
+}
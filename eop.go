@@ -0,0 +1,303 @@
+package sgp4go
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EOPTable is a daily series of Earth orientation parameters, which
+// are given for 0h UTC of each day.
+//
+// A nil *EOPTable is valid and has no data.
+type EOPTable struct {
+	// Leaps (if not nil) is the table of leap seconds for the EOPs
+	// from At().  See EOP.Leaps.
+	Leaps *LeapSeconds
+
+	mjds []float64
+	eops []EOP
+}
+
+// Len returns the number of days in the table.
+func (tab *EOPTable) Len() int {
+	if tab == nil {
+		return 0
+	}
+	return len(tab.mjds)
+}
+
+// add appends a day, which must follow the previous one.
+func (tab *EOPTable) add(mjd float64, eop EOP) error {
+	if n := len(tab.mjds); 0 < n && mjd <= tab.mjds[n-1] {
+		return fmt.Errorf("EOP MJD %v does not follow %v", mjd, tab.mjds[n-1])
+	}
+	tab.mjds = append(tab.mjds, mjd)
+	tab.eops = append(tab.eops, eop)
+	return nil
+}
+
+// mjd returns the given time's (UTC) modified Julian date.
+func mjd(t time.Time) float64 {
+	return toJulianDate(t).MJD()
+}
+
+// At returns the EOP for the given time by linear interpolation.
+//
+// If the time is outside of the table, the result is the zero EOP
+// (which ignores Earth orientation), and ok is false.
+//
+// UT1-UTC jumps by a second at each leap second, which takes effect
+// at the start of the following day, so the interpolation of UT1-UTC
+// during the day of a leap second removes the jump.
+func (tab *EOPTable) At(t time.Time) (eop EOP, ok bool) {
+	if tab == nil {
+		return EOP{}, false
+	}
+	eop, ok = tab.at(t)
+	eop.Leaps = tab.Leaps
+	return eop, ok
+}
+
+// at is At() without the Leaps.
+func (tab *EOPTable) at(t time.Time) (eop EOP, ok bool) {
+	n := tab.Len()
+	if n == 0 {
+		return EOP{}, false
+	}
+
+	x := mjd(t)
+	if x < tab.mjds[0] || tab.mjds[n-1] < x {
+		return EOP{}, false
+	}
+	i := sort.SearchFloat64s(tab.mjds, x)
+	if tab.mjds[i] == x {
+		return tab.eops[i], true
+	}
+
+	var (
+		a, b = tab.eops[i-1], tab.eops[i]
+		f    = (x - tab.mjds[i-1]) / (tab.mjds[i] - tab.mjds[i-1])
+		lerp = func(a, b float64) float64 {
+			return a + f*(b-a)
+		}
+		ut1 = b.UT1MinusUTC - math.Round(b.UT1MinusUTC-a.UT1MinusUTC)
+	)
+	return EOP{
+		UT1MinusUTC: lerp(a.UT1MinusUTC, ut1),
+		LOD:         lerp(a.LOD, b.LOD),
+		XP:          lerp(a.XP, b.XP),
+		YP:          lerp(a.YP, b.YP),
+		DPsi:        lerp(a.DPsi, b.DPsi),
+		DEps:        lerp(a.DEps, b.DEps),
+	}, true
+}
+
+// finalsField parses the given (1-based, inclusive) columns of an
+// IERS finals line, which are zero if blank (or missing).
+func finalsField(line string, start, end int, p *float64, err *error) {
+	if *err != nil || len(line) < start {
+		return
+	}
+	if len(line) < end {
+		end = len(line)
+	}
+	s := strings.TrimSpace(line[start-1 : end])
+	if s == "" {
+		return
+	}
+	x, e := strconv.ParseFloat(s, 64)
+	if e != nil {
+		*err = fmt.Errorf("columns %d-%d %q: %w", start, end, s, e)
+		return
+	}
+	*p = x
+}
+
+// parseFinals parses IERS finals data.  If nutation is true, columns
+// 98-125 are the IAU-1980 nutation corrections.
+func parseFinals(r io.Reader, nutation bool) (*EOPTable, error) {
+	var (
+		tab = &EOPTable{}
+		s   = bufio.NewScanner(r)
+		n   int
+	)
+	for s.Scan() {
+		n++
+		line := s.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		var (
+			mjd, lod float64
+			eop      EOP
+			err      error
+		)
+		finalsField(line, 8, 15, &mjd, &err)
+		finalsField(line, 19, 27, &eop.XP, &err)
+		finalsField(line, 38, 46, &eop.YP, &err)
+		finalsField(line, 59, 68, &eop.UT1MinusUTC, &err)
+		finalsField(line, 80, 86, &lod, &err)
+		if nutation {
+			finalsField(line, 98, 106, &eop.DPsi, &err)
+			finalsField(line, 117, 125, &eop.DEps, &err)
+		}
+		if err == nil && (len(line) < 68 || strings.TrimSpace(line[58:68]) == "") {
+			// The end of the predictions.
+			continue
+		}
+		if err == nil {
+			eop.LOD = lod / 1e3
+			eop.DPsi /= 1e3
+			eop.DEps /= 1e3
+			err = tab.add(mjd, eop)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("IERS finals line %d: %w", n, err)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return tab, nil
+}
+
+// ParseFinalsAll parses IERS finals.all (or finals.data or
+// finals.daily) data, which has IAU-1980 nutation corrections.
+//
+// Days without UT1-UTC (at the end of the predictions) are skipped.
+func ParseFinalsAll(r io.Reader) (*EOPTable, error) {
+	return parseFinals(r, true)
+}
+
+// ParseFinals2000A parses IERS finals2000A.all (or .data or .daily)
+// data.  Its IAU-2000 nutation corrections (dX and dY) don't apply to
+// IAU-1980 nutation and are ignored.
+//
+// Days without UT1-UTC (at the end of the predictions) are skipped.
+func ParseFinals2000A(r io.Reader) (*EOPTable, error) {
+	return parseFinals(r, false)
+}
+
+// ParseCelesTrakEOP parses CelesTrak's EOP-All.txt (or EOP-Last5Years.txt)
+// data, using both the observed and predicted sections.
+func ParseCelesTrakEOP(r io.Reader) (*EOPTable, error) {
+	var (
+		tab     = &EOPTable{}
+		s       = bufio.NewScanner(r)
+		n       int
+		section bool
+	)
+	for s.Scan() {
+		n++
+		line := strings.TrimSpace(s.Text())
+		switch {
+		case line == "BEGIN OBSERVED" || line == "BEGIN PREDICTED":
+			section = true
+			continue
+		case strings.HasPrefix(line, "END "):
+			section = false
+			continue
+		case !section || line == "" || strings.HasPrefix(line, "#"):
+			continue
+		}
+
+		fs := strings.Fields(line)
+		if len(fs) < 10 {
+			return nil, fmt.Errorf("CelesTrak EOP line %d: %d fields", n, len(fs))
+		}
+		var (
+			xs  = make([]float64, 7)
+			err error
+		)
+		for i := range xs {
+			if xs[i], err = strconv.ParseFloat(fs[3+i], 64); err != nil {
+				return nil, fmt.Errorf("CelesTrak EOP line %d: %w", n, err)
+			}
+		}
+		eop := EOP{
+			XP:          xs[1],
+			YP:          xs[2],
+			UT1MinusUTC: xs[3],
+			LOD:         xs[4],
+			DPsi:        xs[5],
+			DEps:        xs[6],
+		}
+		if err := tab.add(xs[0], eop); err != nil {
+			return nil, fmt.Errorf("CelesTrak EOP line %d: %w", n, err)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return tab, nil
+}
+
+// ParseCelesTrakEOPCSV parses CelesTrak's EOP-All.csv (or
+// EOP-Last5Years.csv) data.  The first record is a header.
+func ParseCelesTrakEOPCSV(r io.Reader) (*EOPTable, error) {
+	rs, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rs) == 0 {
+		return nil, fmt.Errorf("no header in CelesTrak EOP CSV")
+	}
+
+	var (
+		tab  = &EOPTable{}
+		cols = make(map[string]int)
+	)
+	for i, h := range rs[0] {
+		cols[strings.ToUpper(strings.TrimSpace(h))] = i
+	}
+	for _, k := range []string{"MJD", "X", "Y", "UT1-UTC", "LOD", "DPSI", "DEPS"} {
+		if _, have := cols[k]; !have {
+			return nil, fmt.Errorf("CelesTrak EOP CSV missing %s", k)
+		}
+	}
+
+	for n, rec := range rs[1:] {
+		var (
+			err error
+			f   = func(k string) float64 {
+				i := cols[k]
+				if err != nil || len(rec) <= i {
+					return 0
+				}
+				s := strings.TrimSpace(rec[i])
+				if s == "" {
+					return 0
+				}
+				var x float64
+				if x, err = strconv.ParseFloat(s, 64); err != nil {
+					err = fmt.Errorf("%s: %w", k, err)
+				}
+				return x
+			}
+			mjd = f("MJD")
+			eop = EOP{
+				XP:          f("X"),
+				YP:          f("Y"),
+				UT1MinusUTC: f("UT1-UTC"),
+				LOD:         f("LOD"),
+				DPsi:        f("DPSI"),
+				DEps:        f("DEPS"),
+			}
+		)
+		if err == nil {
+			err = tab.add(mjd, eop)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("CelesTrak EOP CSV record %d: %w", n+1, err)
+		}
+	}
+	return tab, nil
+}
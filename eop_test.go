@@ -0,0 +1,163 @@
+package sgp4go
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Sample data (spanning the leap second at the end of 2016) in the
+// IERS finals and CelesTrak formats.
+var (
+	finalsAll = `161230 57752.00 I  0.035466 0.000091  0.281832 0.000076  I-0.4081234 0.0000121  0.8032 0.0081  I  -104.521    0.282   -10.213    0.300
+161231 57753.00 I  0.034198 0.000091  0.282667 0.000076  I-0.4089470 0.0000121  0.8420 0.0081  I  -104.650    0.282   -10.310    0.300
+170101 57754.00 I  0.032885 0.000091  0.283581 0.000076  I 0.5912290 0.0000121  0.9013 0.0081  I  -104.744    0.282   -10.380    0.300
+170102 57755.00
+`
+
+	celesTrakEOP = `VERSION 1.2
+UPDATED 2017 Jan 02 04:57:37 UTC
+# FORMAT(I4,I3,I3,I6,2F10.6,2F11.7,4F10.6,I4)
+NUM_OBSERVED_POINTS      3
+BEGIN OBSERVED
+2016 12 30 57752  0.035466  0.281832 -0.4081234  0.0008032 -0.104521 -0.010213  0.000000  0.000000  36
+2016 12 31 57753  0.034198  0.282667 -0.4089470  0.0008420 -0.104650 -0.010310  0.000000  0.000000  36
+END OBSERVED
+NUM_PREDICTED_POINTS      1
+BEGIN PREDICTED
+2017 01 01 57754  0.032885  0.283581  0.5912290  0.0009013 -0.104744 -0.010380  0.000000  0.000000  37
+END PREDICTED
+`
+
+	celesTrakEOPCSV = `DATE,MJD,X,Y,UT1-UTC,LOD,DPSI,DEPS,DX,DY,DAT,DATA_TYPE
+2016-12-30,57752,0.035466,0.281832,-0.4081234,0.0008032,-0.104521,-0.010213,0.000000,0.000000,36,O
+2016-12-31,57753,0.034198,0.282667,-0.4089470,0.0008420,-0.104650,-0.010310,0.000000,0.000000,36,O
+2017-01-01,57754,0.032885,0.283581,0.5912290,0.0009013,-0.104744,-0.010380,0.000000,0.000000,37,P
+`
+)
+
+func TestEOPTable(t *testing.T) {
+	var (
+		day  = time.Date(2016, 12, 30, 0, 0, 0, 0, time.UTC)
+		want = EOP{UT1MinusUTC: -0.4081234, LOD: 0.0008032, XP: 0.035466, YP: 0.281832, DPsi: -0.104521, DEps: -0.010213}
+	)
+
+	check := func(t *testing.T, tab *EOPTable, nutation bool) {
+		if tab.Len() != 3 {
+			t.Fatal(tab.Len())
+		}
+
+		w := want
+		if !nutation {
+			w.DPsi, w.DEps = 0, 0
+		}
+		if eop, ok := tab.At(day); !ok || eop != w {
+			t.Fatal(eop, ok)
+		}
+
+		// Halfway through the day.
+		eop, ok := tab.At(day.Add(12 * time.Hour))
+		if !ok || 1e-9 < math.Abs(eop.XP-(0.035466+0.034198)/2) {
+			t.Fatal(eop, ok)
+		}
+
+		// Halfway through the day with the leap second.
+		eop, ok = tab.At(day.Add(36 * time.Hour))
+		if !ok || 1e-9 < math.Abs(eop.UT1MinusUTC-(-0.4089470+0.5912290-1)/2) {
+			t.Fatal(eop, ok)
+		}
+
+		// The last day.
+		if eop, ok := tab.At(day.Add(48 * time.Hour)); !ok || eop.UT1MinusUTC != 0.5912290 {
+			t.Fatal(eop, ok)
+		}
+
+		for _, d := range []time.Duration{-time.Second, 48*time.Hour + time.Second} {
+			if eop, ok := tab.At(day.Add(d)); ok || eop != (EOP{}) {
+				t.Fatal(eop, ok)
+			}
+		}
+	}
+
+	t.Run("finals.all", func(t *testing.T) {
+		tab, err := ParseFinalsAll(strings.NewReader(finalsAll))
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, tab, true)
+	})
+
+	t.Run("finals2000A", func(t *testing.T) {
+		tab, err := ParseFinals2000A(strings.NewReader(finalsAll))
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, tab, false)
+	})
+
+	t.Run("CelesTrak", func(t *testing.T) {
+		tab, err := ParseCelesTrakEOP(strings.NewReader(celesTrakEOP))
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, tab, true)
+	})
+
+	t.Run("CelesTrakCSV", func(t *testing.T) {
+		tab, err := ParseCelesTrakEOPCSV(strings.NewReader(celesTrakEOPCSV))
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, tab, true)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var tab *EOPTable
+		if eop, ok := tab.At(day); ok || eop != (EOP{}) {
+			t.Fatal(eop, ok)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		lines := strings.Split(finalsAll, "\n")
+		if _, err := ParseFinalsAll(strings.NewReader(lines[1] + "\n" + lines[0])); err == nil {
+			t.Fatal("out of order")
+		}
+		bad := lines[0][:60] + "x" + lines[0][61:]
+		if _, err := ParseFinalsAll(strings.NewReader(bad)); err == nil {
+			t.Fatal("bad UT1-UTC")
+		}
+	})
+}
+
+func TestEOPFrames(t *testing.T) {
+	tab, err := ParseFinalsAll(strings.NewReader(finalsAll))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		at      = time.Date(2016, 12, 31, 12, 0, 0, 0, time.UTC)
+		eop, ok = tab.At(at)
+		r       = Vect{7000, 0, 0}
+	)
+	if !ok {
+		t.Fatal(ok)
+	}
+
+	// UT1 is about 0.409 sec behind UTC (rather than a second ahead,
+	// which it is after the leap second).
+	ut1 := (-0.4089470 + 0.5912290 - 1) / 2
+	if 1e-9 < math.Abs(eop.UT1MinusUTC-ut1) {
+		t.Fatal(eop)
+	}
+	if d := GMST(at, EOP{}) - GMST(at, eop); 1e-8 < math.Abs(d+ut1*earthRotation) {
+		t.Fatal(d)
+	}
+
+	// That's about 200 meters at 7000 km.
+	r1, _ := TEMEToECEF(at, r, Vect{}, eop)
+	r0, _ := TEMEToECEF(at, r, Vect{}, EOP{})
+	near(t, "ECEF", r1, r0, 0.22)
+}
@@ -0,0 +1,167 @@
+package sgp4go
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Format renders the TLE's current values as two 69-column lines
+// (with checksums).
+//
+// Unlike Lines(), the result reflects any changes made by Set().  An
+// error is returned if a value cannot be represented in the TLE
+// format (e.g., an eccentricity of 1 or more).
+func (tle *TLE) Format() (string, string, error) {
+	var (
+		rec  = &tle.Rec
+		yr   = rec.epochyr
+		days = math.Round(rec.epochdays*1e8) / 1e8
+	)
+
+	// Rounding can push the epoch into the next year.
+	if daysInYear(fullYear(yr)) < int(days) {
+		yr, days = (yr+1)%100, days-float64(daysInYear(fullYear(yr)))
+	}
+
+	ndot, err := formatDot(tle.ndot)
+	if err != nil {
+		return "", "", err
+	}
+	nddot, err := formatExp("second derivative of mean motion", tle.nddot)
+	if err != nil {
+		return "", "", err
+	}
+	bstar, err := formatExp("B*", tle.bstar)
+	if err != nil {
+		return "", "", err
+	}
+
+	catnum, err := FormatAlpha5(tle.objectNum)
+	if err != nil {
+		return "", "", err
+	}
+	if tle.elnum < 0 || 9999 < tle.elnum {
+		return "", "", fmt.Errorf("element set number %d out of range", tle.elnum)
+	}
+	ecc := math.Round(tle.ecc * 1e7)
+	if ecc < 0 || 1e7 <= ecc {
+		return "", "", fmt.Errorf("eccentricity %v out of range", tle.ecc)
+	}
+	if tle.n < 0 || 100 <= tle.n {
+		return "", "", fmt.Errorf("mean motion %v out of range", tle.n)
+	}
+
+	class := rec.classification
+	if class == 0 {
+		class = 'U'
+	}
+	ephtype := rec.ephtype
+	if ephtype < 0 || 9 < ephtype {
+		ephtype = 0
+	}
+	intlid := string(bytes.Trim(tle.intlid[:], "\x00"))
+
+	line1 := fmt.Sprintf("1 %s%c %-8.8s %02d%012.8f %s %s %s %d %4d",
+		catnum, class, intlid, yr, days, ndot, nddot, bstar, ephtype, tle.elnum)
+	line2 := fmt.Sprintf("2 %s %8.4f %8.4f %07d %8.4f %8.4f %11.8f%5d",
+		catnum, tle.incDeg, angle(tle.raanDeg), int64(ecc),
+		angle(tle.argpDeg), angle(tle.maDeg), tle.n, tle.revnum%100000)
+
+	line1 += fmt.Sprintf("%d", Checksum(line1))
+	line2 += fmt.Sprintf("%d", Checksum(line2))
+
+	return line1, line2, nil
+}
+
+// formatDot renders the first derivative of mean motion like
+// " .00001103".
+func formatDot(x float64) (string, error) {
+	s := fmt.Sprintf("%.8f", math.Abs(x))
+	if !strings.HasPrefix(s, "0.") {
+		return "", fmt.Errorf("first derivative of mean motion %v out of range", x)
+	}
+	return sign(x) + s[1:], nil
+}
+
+// formatExp renders a value with an implied leading decimal point and
+// an exponent like " 27992-4".
+func formatExp(name string, x float64) (string, error) {
+	if x == 0 {
+		return " 00000-0", nil
+	}
+	var (
+		e = math.Floor(math.Log10(math.Abs(x))) + 1
+		m = math.Round(math.Abs(x) / math.Pow(10, e) * 1e5)
+	)
+	if m == 1e5 {
+		m, e = 1e4, e+1
+	}
+	if e < -9 || 9 < e {
+		return "", fmt.Errorf("%s %v out of range", name, x)
+	}
+	esign := "-"
+	if 0 < e {
+		esign = "+"
+	}
+	return fmt.Sprintf("%s%05d%s%d", sign(x), int64(m), esign, int64(math.Abs(e))), nil
+}
+
+// sign returns "-" for negative numbers and " " otherwise.
+func sign(x float64) string {
+	if x < 0 {
+		return "-"
+	}
+	return " "
+}
+
+// angle rounds the given degrees to the four decimals of a TLE and
+// then normalizes them to [0,360), so (e.g.) 359.99999 is 0.
+func angle(deg float64) float64 {
+	deg = math.Mod(math.Round(deg*1e4)/1e4, 360)
+	switch {
+	case deg < 0:
+		deg += 360
+	case deg == 0:
+		// Not -0, which would be formatted as "-0.0000".
+		deg = 0
+	}
+	return deg
+}
+
+// fullYear returns the four-digit year for the given two-digit TLE
+// epoch year.
+func fullYear(yr int64) int {
+	if 56 < yr {
+		return int(1900 + yr)
+	}
+	return int(2000 + yr)
+}
+
+func daysInYear(year int) int {
+	if isLeap(int64(year)) == 1 {
+		return 366
+	}
+	return 365
+}
+
+// setEpoch updates all of the TLE's representations of its epoch.
+func setEpoch(tle *TLE, t time.Time) {
+	t = t.UTC()
+	var (
+		rec  = &tle.Rec
+		secs = float64(t.Hour()*3600+t.Minute()*60+t.Second()) + float64(t.Nanosecond())/1e9
+		jd   = toJulianDate(t)
+	)
+
+	rec.epochyr = int64(t.Year() % 100)
+	rec.epochdays = float64(t.YearDay()) + secs/86400
+	rec.jdsatepoch, rec.jdsatepochF = jd.Day, jd.Frac
+}
+
+// epochTime returns the TLE's epoch from its (two-part) Julian date.
+func epochTime(tle *TLE) time.Time {
+	return fromJulianDate(JulianDate{tle.Rec.jdsatepoch, tle.Rec.jdsatepochF})
+}
@@ -0,0 +1,91 @@
+package sgp4go
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormat(t *testing.T) {
+	var (
+		lines = strings.Split(ISS, "\n")
+		o     = getExample(t)
+	)
+
+	line1, line2, err := o.Format()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if line1 != lines[1] {
+		t.Fatalf("\n%s\n%s", line1, lines[1])
+	}
+	if line2 != lines[2] {
+		t.Fatalf("\n%s\n%s", line2, lines[2])
+	}
+}
+
+func TestFormatVER(t *testing.T) {
+	for _, pair := range verLines(t) {
+		x, err := NewTLE(pair[0], pair[1], WithChecksum(ChecksumIgnore))
+		var ie *InitError
+		if errors.As(err, &ie) {
+			// 33334 deliberately has bad elements.
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		line1, line2, err := x.Format()
+		if err != nil {
+			t.Fatal(err)
+		}
+		y, err := NewTLE(line1, line2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !x.EqualValues(y) {
+			t.Fatalf("\n%s\n%s\n%s\n%s", pair[0], pair[1], line1, line2)
+		}
+	}
+}
+
+func TestFormatSet(t *testing.T) {
+	var (
+		o     = getExample(t)
+		epoch = time.Date(2021, 1, 2, 12, 0, 0, 0, time.UTC)
+	)
+
+	if err := o.Set(epoch, 0, 0, -1.2345e-5, 97.5, 0, 0, 0, 0, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	line1, line2, err := o.Format()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "1 25544U 98067A   21002.50000000  .00001103  00000-0 -12345-4 0  9995"; line1 != want {
+		t.Fatalf("\n%s\n%s", line1, want)
+	}
+	if want := "2 25544  97.5000 177.3570 0001731 128.2351  43.6939 15.49184106259938"; line2 != want {
+		t.Fatalf("\n%s\n%s", line2, want)
+	}
+}
+
+func TestFormatAngles(t *testing.T) {
+	o := getExample(t)
+
+	// The angles round to 360 and -0, which are out of range.
+	if err := o.Set(time.Time{}, 0, 0, 0, 0, 359.99999, 0, -0.00001, 719.99996, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	_, line2, err := o.Format()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "2 25544  51.6443   0.0000 0001731   0.0000   0.0000 15.4918410625993"
+	if want += fmt.Sprint(Checksum(want)); line2 != want {
+		t.Fatalf("\n%s\n%s", line2, want)
+	}
+}
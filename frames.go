@@ -0,0 +1,395 @@
+package sgp4go
+
+import (
+	"math"
+	"time"
+)
+
+// SGP4 produces states in the True Equator, Mean Equinox (TEME)
+// frame.  The functions here convert TEME states to other frames
+// following Vallado et al., "Revisiting Spacetrack Report #3" (2006)
+// and Vallado, "Fundamentals of Astrodynamics and Applications".
+//
+// Precession and nutation use Terrestrial Time, which depends on the
+// leap seconds in EOP.Leaps.
+
+// EOP are Earth orientation parameters.
+//
+// The zero value ignores Earth orientation.  Since |UT1-UTC| < 0.9
+// sec, the resulting Earth-fixed positions are off by less than about
+// half a kilometer (and usually by much less).
+type EOP struct {
+	// UT1MinusUTC is UT1-UTC in seconds.
+	UT1MinusUTC float64
+
+	// LOD is the excess length of day in seconds.
+	LOD float64
+
+	// XP and YP are the polar motion coordinates in arcseconds.
+	XP, YP float64
+
+	// DPsi and DEps are corrections to IAU-1980 nutation in longitude
+	// and obliquity in arcseconds.
+	DPsi, DEps float64
+
+	// Leaps is the table of leap seconds for Terrestrial Time
+	// (DefaultLeapSeconds if nil), such as from ParseLeapSecondDat().
+	// See EOPTable.Leaps.
+	Leaps *LeapSeconds
+}
+
+const (
+	// arcsec is an arcsecond in radians.
+	arcsec = math.Pi / (180 * 3600)
+
+	// earthRotation is the Earth's nominal rotation rate in rad/sec.
+	earthRotation = 7.292115146706979e-5
+)
+
+// mat3 is a 3x3 matrix.
+type mat3 [3][3]float64
+
+// mul returns m v.
+func (m *mat3) mul(v Vect) Vect {
+	return Vect{
+		m[0][0]*v.X + m[0][1]*v.Y + m[0][2]*v.Z,
+		m[1][0]*v.X + m[1][1]*v.Y + m[1][2]*v.Z,
+		m[2][0]*v.X + m[2][1]*v.Y + m[2][2]*v.Z,
+	}
+}
+
+// tmul returns the transpose of m times v.
+func (m *mat3) tmul(v Vect) Vect {
+	return Vect{
+		m[0][0]*v.X + m[1][0]*v.Y + m[2][0]*v.Z,
+		m[0][1]*v.X + m[1][1]*v.Y + m[2][1]*v.Z,
+		m[0][2]*v.X + m[1][2]*v.Y + m[2][2]*v.Z,
+	}
+}
+
+// cross returns a x b.
+func cross(a, b Vect) Vect {
+	return Vect{
+		a.Y*b.Z - a.Z*b.Y,
+		a.Z*b.X - a.X*b.Z,
+		a.X*b.Y - a.Y*b.X,
+	}
+}
+
+// dot returns a . b.
+func dot(a, b Vect) float64 {
+	return a.X*b.X + a.Y*b.Y + a.Z*b.Z
+}
+
+// norm returns the length of v.
+func norm(v Vect) float64 {
+	return math.Sqrt(dot(v, v))
+}
+
+// GMST returns the Greenwich mean sidereal time in radians for the
+// given UTC time, which is converted to UT1 using the EOP (see
+// EOPTable).
+func GMST(t time.Time, eop EOP) float64 {
+	jd := toJulianDate(t)
+	return gstime(jd.Day + jd.Frac + eop.UT1MinusUTC/86400)
+}
+
+// polarMotion returns the IAU-76/FK5 polar motion matrix, which
+// converts ITRF to PEF.
+func polarMotion(eop EOP) mat3 {
+	var (
+		sxp, cxp = math.Sincos(eop.XP * arcsec)
+		syp, cyp = math.Sincos(eop.YP * arcsec)
+	)
+	return mat3{
+		{cxp, 0, -sxp},
+		{sxp * syp, cyp, cxp * syp},
+		{sxp * cyp, -syp, cxp * cyp},
+	}
+}
+
+// TEMEToPEF converts a TEME position (km) and velocity (km/sec) at the
+// given (UTC) time to the Pseudo Earth Fixed (PEF) frame, which
+// rotates with the Earth but ignores polar motion.
+//
+// The velocity includes the Earth's rotation, which the LOD
+// adjusts.
+func TEMEToPEF(t time.Time, r, v Vect, eop EOP) (Vect, Vect) {
+	var (
+		s, c = math.Sincos(GMST(t, eop))
+		st   = mat3{
+			{c, s, 0},
+			{-s, c, 0},
+			{0, 0, 1},
+		}
+		omega = Vect{0, 0, earthRotation * (1 - eop.LOD/86400)}
+		rpef  = st.mul(r)
+		vpef  = st.mul(v)
+		wxr   = cross(omega, rpef)
+	)
+	return rpef, Vect{vpef.X - wxr.X, vpef.Y - wxr.Y, vpef.Z - wxr.Z}
+}
+
+// TEMEToECEF converts a TEME position (km) and velocity (km/sec) at
+// the given (UTC) time to an Earth-Centered, Earth-Fixed (ECEF) frame,
+// which is ITRF when the EOP are given.
+//
+// Also see TEMEToPEF().
+func TEMEToECEF(t time.Time, r, v Vect, eop EOP) (Vect, Vect) {
+	var (
+		rpef, vpef = TEMEToPEF(t, r, v, eop)
+		pm         = polarMotion(eop)
+	)
+	return pm.tmul(rpef), pm.tmul(vpef)
+}
+
+// ECEF converts the Ephemeris (which is TEME) at the given time to
+// ECEF.  See TEMEToECEF().
+func (e Ephemeris) ECEF(t time.Time, eop EOP) (Vect, Vect) {
+	return TEMEToECEF(t, e.ECI, e.V, eop)
+}
+
+// nutationTerms are the terms of the IAU-1980 nutation series with
+// longitude coefficients of at least 0.0005 arcseconds: multipliers
+// of the fundamental arguments (l, l', F, D, and the node) and the
+// coefficients of the longitude and obliquity in 0.0001 arcseconds
+// (A + B T and C + D T).
+//
+// The full series has 106 terms.  The omitted terms change the
+// nutation by about a milliarcsecond, which is about 20 centimeters
+// at geosynchronous altitude.
+var nutationTerms = [][9]float64{
+	{0, 0, 0, 0, 1, -171996, -174.2, 92025, 8.9},
+	{0, 0, 2, -2, 2, -13187, -1.6, 5736, -3.1},
+	{0, 0, 2, 0, 2, -2274, -0.2, 977, -0.5},
+	{0, 0, 0, 0, 2, 2062, 0.2, -895, 0.5},
+	{0, 1, 0, 0, 0, 1426, -3.4, 54, -0.1},
+	{1, 0, 0, 0, 0, 712, 0.1, -7, 0},
+	{0, 1, 2, -2, 2, -517, 1.2, 224, -0.6},
+	{0, 0, 2, 0, 1, -386, -0.4, 200, 0},
+	{1, 0, 2, 0, 2, -301, 0, 129, -0.1},
+	{0, -1, 2, -2, 2, 217, -0.5, -95, 0.3},
+	{1, 0, 0, -2, 0, -158, 0, -1, 0},
+	{0, 0, 2, -2, 1, 129, 0.1, -70, 0},
+	{-1, 0, 2, 0, 2, 123, 0, -53, 0},
+	{1, 0, 0, 0, 1, 63, 0.1, -33, 0},
+	{0, 0, 0, 2, 0, 63, 0, -2, 0},
+	{-1, 0, 2, 2, 2, -59, 0, 26, 0},
+	{-1, 0, 0, 0, 1, -58, -0.1, 32, 0},
+	{1, 0, 2, 0, 1, -51, 0, 27, 0},
+	{2, 0, 0, -2, 0, 48, 0, 1, 0},
+	{-2, 0, 2, 0, 1, 46, 0, -24, 0},
+	{0, 0, 2, 2, 2, -38, 0, 16, 0},
+	{2, 0, 2, 0, 2, -31, 0, 13, 0},
+	{2, 0, 0, 0, 0, 29, 0, -1, 0},
+	{1, 0, 2, -2, 2, 29, 0, -12, 0},
+	{0, 0, 2, 0, 0, 26, 0, -1, 0},
+	{0, 0, 2, -2, 0, -22, 0, 0, 0},
+	{-1, 0, 2, 0, 1, 21, 0, -10, 0},
+	{0, 2, 0, 0, 0, 17, -0.1, 0, 0},
+	{0, 2, 2, -2, 2, -16, 0.1, 7, 0},
+	{-1, 0, 0, 2, 1, 16, 0, -8, 0},
+	{0, 1, 0, 0, 1, -15, 0, 9, 0},
+	{1, 0, 0, -2, 1, -13, 0, 7, 0},
+	{0, -1, 0, 0, 1, -12, 0, 6, 0},
+	{2, 0, -2, 0, 0, 11, 0, 0, 0},
+	{-1, 0, 2, 2, 1, -10, 0, 5, 0},
+	{1, 0, 2, 2, 2, -8, 0, 3, 0},
+	{0, -1, 2, 0, 2, -7, 0, 3, 0},
+	{0, 0, 2, 2, 1, -7, 0, 3, 0},
+	{1, 1, 0, -2, 0, -7, 0, 0, 0},
+	{0, 1, 2, 0, 2, 7, 0, -3, 0},
+	{-2, 0, 0, 2, 1, -6, 0, 3, 0},
+	{0, 0, 0, 2, 1, -6, 0, 3, 0},
+	{2, 0, 2, -2, 2, 6, 0, -3, 0},
+	{1, 0, 0, 2, 0, 6, 0, 0, 0},
+	{1, 0, 2, -2, 1, 6, 0, -3, 0},
+	{0, 0, 0, -2, 1, -5, 0, 3, 0},
+	{0, -1, 2, -2, 1, -5, 0, 3, 0},
+	{2, 0, 2, 0, 1, -5, 0, 3, 0},
+	{1, -1, 0, 0, 0, 5, 0, 0, 0},
+}
+
+// centuries returns the Julian centuries (TT) since J2000 for the
+// given (UTC) time with the given leap seconds (DefaultLeapSeconds if
+// nil).
+func centuries(t time.Time, leaps *LeapSeconds) float64 {
+	ts := TimeScales{Leaps: leaps}
+	return ts.JulianDate(t, TT).Centuries()
+}
+
+// nutation returns the IAU-1980 nutation in longitude and obliquity
+// and the mean obliquity (all in radians) including any corrections
+// in the EOP.
+func nutation(ttt float64, eop EOP) (dpsi, deps, meaneps float64) {
+	return truncatedNutation(ttt, eop, len(nutationTerms))
+}
+
+// truncatedNutation is nutation() with only the first n terms of the
+// series.
+func truncatedNutation(ttt float64, eop EOP, n int) (dpsi, deps, meaneps float64) {
+	deg := func(c0, c1, c2, c3 float64) float64 {
+		return math.Mod((((c3*ttt+c2)*ttt+c1)*ttt)/3600+c0, 360) * math.Pi / 180
+	}
+	var (
+		l     = deg(134.96298139, 1717915922.6330, 31.310, 0.064)
+		l1    = deg(357.52772333, 129596581.2240, -0.577, -0.012)
+		f     = deg(93.27191028, 1739527263.1370, -13.257, 0.011)
+		d     = deg(297.85036306, 1602961601.3280, -6.891, 0.019)
+		omega = deg(125.04452222, -6962890.5390, 7.455, 0.008)
+	)
+
+	for _, c := range nutationTerms[:n] {
+		arg := c[0]*l + c[1]*l1 + c[2]*f + c[3]*d + c[4]*omega
+		dpsi += (c[5] + c[6]*ttt) * math.Sin(arg)
+		deps += (c[7] + c[8]*ttt) * math.Cos(arg)
+	}
+	dpsi = dpsi*1e-4*arcsec + eop.DPsi*arcsec
+	deps = deps*1e-4*arcsec + eop.DEps*arcsec
+
+	meaneps = (((0.001813*ttt-0.00059)*ttt-46.8150)*ttt + 84381.448) * arcsec
+
+	return dpsi, deps, meaneps
+}
+
+// equinoxMatrix converts TEME to TOD by rotating by the equation of
+// the equinoxes.
+func equinoxMatrix(dpsi, meaneps float64) mat3 {
+	s, c := math.Sincos(dpsi * math.Cos(meaneps))
+	return mat3{
+		{c, -s, 0},
+		{s, c, 0},
+		{0, 0, 1},
+	}
+}
+
+// nutationMatrix converts TOD to MOD.
+func nutationMatrix(dpsi, deps, meaneps float64) mat3 {
+	var (
+		sp, cp = math.Sincos(dpsi)
+		se, ce = math.Sincos(meaneps)
+		st, ct = math.Sincos(meaneps + deps)
+	)
+	return mat3{
+		{cp, ct * sp, st * sp},
+		{-ce * sp, ct*ce*cp + st*se, st*ce*cp - se*ct},
+		{-se * sp, ct*se*cp - st*ce, st*se*cp + ct*ce},
+	}
+}
+
+// precessionMatrix converts MOD to J2000 using IAU-1976 precession.
+func precessionMatrix(ttt float64) mat3 {
+	var (
+		zeta  = ((0.017998*ttt+0.30188)*ttt + 2306.2181) * ttt * arcsec
+		theta = ((-0.041833*ttt-0.42665)*ttt + 2004.3109) * ttt * arcsec
+		z     = ((0.018203*ttt+1.09468)*ttt + 2306.2181) * ttt * arcsec
+
+		szeta, czeta   = math.Sincos(zeta)
+		stheta, ctheta = math.Sincos(theta)
+		sz, cz         = math.Sincos(z)
+	)
+	return mat3{
+		{czeta*ctheta*cz - szeta*sz, czeta*ctheta*sz + szeta*cz, czeta * stheta},
+		{-szeta*ctheta*cz - czeta*sz, -szeta*ctheta*sz + czeta*cz, -szeta * stheta},
+		{-stheta * cz, -stheta * sz, ctheta},
+	}
+}
+
+// frameBias converts J2000 (FK5) to GCRF.  It's the transpose of the
+// IERS frame bias matrix (to first order).
+var frameBias = func() mat3 {
+	var (
+		xi0  = -0.0166170 * arcsec
+		eta0 = -0.0068192 * arcsec
+		da0  = -0.01460 * arcsec
+	)
+	return mat3{
+		{1, -da0, xi0},
+		{da0, 1, eta0},
+		{-xi0, -eta0, 1},
+	}
+}()
+
+// TEMEToTOD converts a TEME position and velocity at the given time
+// to the True of Date (TOD) frame, which differs by the equation of
+// the equinoxes.
+//
+// Only the DPsi, DEps, and Leaps EOP are used.
+func TEMEToTOD(t time.Time, r, v Vect, eop EOP) (Vect, Vect) {
+	var (
+		dpsi, _, meaneps = nutation(centuries(t, eop.Leaps), eop)
+		eqe              = equinoxMatrix(dpsi, meaneps)
+	)
+	return eqe.mul(r), eqe.mul(v)
+}
+
+// TEMEToMOD converts a TEME position and velocity at the given time
+// to the Mean of Date (MOD) frame using IAU-1980 nutation.
+//
+// Only the DPsi, DEps, and Leaps EOP are used.
+func TEMEToMOD(t time.Time, r, v Vect, eop EOP) (Vect, Vect) {
+	var (
+		dpsi, deps, meaneps = nutation(centuries(t, eop.Leaps), eop)
+		eqe                 = equinoxMatrix(dpsi, meaneps)
+		nut                 = nutationMatrix(dpsi, deps, meaneps)
+	)
+	return nut.mul(eqe.mul(r)), nut.mul(eqe.mul(v))
+}
+
+// TEMEToJ2000 converts a TEME position and velocity at the given time
+// to the J2000 (FK5 mean equator and equinox of J2000, also known as
+// EME2000) frame using IAU-1976 precession and IAU-1980 nutation.
+//
+// With DPsi and DEps EOP, the result is a good approximation of GCRF
+// (see Vallado).  Otherwise see TEMEToGCRF().
+func TEMEToJ2000(t time.Time, r, v Vect, eop EOP) (Vect, Vect) {
+	return temeToJ2000(t, r, v, eop, len(nutationTerms))
+}
+
+// temeToJ2000 is TEMEToJ2000() with only the first n terms of the
+// nutation series.
+func temeToJ2000(t time.Time, r, v Vect, eop EOP, n int) (Vect, Vect) {
+	var (
+		ttt                 = centuries(t, eop.Leaps)
+		dpsi, deps, meaneps = truncatedNutation(ttt, eop, n)
+		eqe                 = equinoxMatrix(dpsi, meaneps)
+		nut                 = nutationMatrix(dpsi, deps, meaneps)
+		prec                = precessionMatrix(ttt)
+	)
+	return prec.mul(nut.mul(eqe.mul(r))), prec.mul(nut.mul(eqe.mul(v)))
+}
+
+// TEMEToGCRF converts a TEME position and velocity at the given time
+// to the Geocentric Celestial Reference Frame (GCRF).
+//
+// The IERS DPsi and DEps EOP (celestial pole offsets from the IAU-1980
+// model) correct TEMEToJ2000() to GCRF, so with them the result is
+// TEMEToJ2000().  Without them, the IERS frame bias is applied to
+// TEMEToJ2000(), which leaves the errors of the IAU-1976/1980 model.
+// Those can be tens of milliarcseconds, which is 2 to 10 meters at
+// geosynchronous altitude.
+//
+// The CIO-based IAU-2006/2000A transformation is not implemented.
+func TEMEToGCRF(t time.Time, r, v Vect, eop EOP) (Vect, Vect) {
+	r, v = TEMEToJ2000(t, r, v, eop)
+	if eop.DPsi != 0 || eop.DEps != 0 {
+		return r, v
+	}
+	return frameBias.mul(r), frameBias.mul(v)
+}
+
+// ECEFToTEME converts an ECEF (ITRF) position (km) and velocity
+// (km/sec) at the given (UTC) time to TEME.  It's the inverse of
+// TEMEToECEF().
+func ECEFToTEME(t time.Time, r, v Vect, eop EOP) (Vect, Vect) {
+	var (
+		pm    = polarMotion(eop)
+		rpef  = pm.mul(r)
+		vpef  = pm.mul(v)
+		s, c  = math.Sincos(GMST(t, eop))
+		st    = mat3{{c, s, 0}, {-s, c, 0}, {0, 0, 1}}
+		omega = Vect{0, 0, earthRotation * (1 - eop.LOD/86400)}
+		wxr   = cross(omega, rpef)
+	)
+	return st.tmul(rpef), st.tmul(Vect{vpef.X + wxr.X, vpef.Y + wxr.Y, vpef.Z + wxr.Z})
+}
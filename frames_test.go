@@ -0,0 +1,156 @@
+package sgp4go
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// vallado0406 is the TEME state from Vallado et al. (2006) and
+// Vallado's Example 3-15, along with its time and EOP.
+var vallado0406 = struct {
+	t    time.Time
+	r, v Vect
+	eop  EOP
+}{
+	t:   time.Date(2004, 4, 6, 7, 51, 28, 386009000, time.UTC),
+	r:   Vect{5094.18016210, 6127.64465950, 6380.34453270},
+	v:   Vect{-4.746131487, 0.785818041, 5.531931288},
+	eop: EOP{UT1MinusUTC: -0.4399619, LOD: 0.0015563, XP: -0.140682, YP: 0.333309},
+}
+
+// near checks that the given vectors differ by less than tol.
+func near(t *testing.T, what string, got, want Vect, tol float64) {
+	t.Helper()
+	d := math.Sqrt((got.X-want.X)*(got.X-want.X) + (got.Y-want.Y)*(got.Y-want.Y) + (got.Z-want.Z)*(got.Z-want.Z))
+	if tol < d {
+		t.Fatalf("%s: got %v want %v (%g)", what, got, want, d)
+	}
+}
+
+func TestTEMEToECEF(t *testing.T) {
+	x := vallado0406
+
+	r, v := TEMEToECEF(x.t, x.r, x.v, x.eop)
+	near(t, "r", r, Vect{-1033.4793830, 7901.2952754, 6380.3565958}, 1e-4)
+	near(t, "v", v, Vect{-3.225636520, -2.872451450, 5.531924446}, 1e-6)
+
+	rpef, vpef := TEMEToPEF(x.t, x.r, x.v, x.eop)
+	if rpef.Z != x.r.Z || vpef.Z != x.v.Z {
+		t.Fatal(rpef, vpef)
+	}
+
+	// Without EOP, the position is off by a few hundred meters.
+	r0, _ := TEMEToECEF(x.t, x.r, x.v, EOP{})
+	near(t, "r0", r0, r, 0.5)
+
+	rt, vt := ECEFToTEME(x.t, r, v, x.eop)
+	near(t, "TEME r", rt, x.r, 1e-9)
+	near(t, "TEME v", vt, x.v, 1e-12)
+
+	e := Ephemeris{V: x.v, ECI: x.r}
+	if r1, v1 := e.ECEF(x.t, x.eop); r1 != r || v1 != v {
+		t.Fatal(r1, v1)
+	}
+}
+
+func TestTEMEToJ2000(t *testing.T) {
+	var (
+		tle = "1 00005U 58002B   00179.78495062  .00000023  00000-0  28098-4 0  4753\n" +
+			"2 00005  34.2682 348.7242 1859667 331.7664  19.3264 10.82419157413667"
+		lines  = strings.Split(tle, "\n")
+		o, err = NewTLE(lines[0], lines[1])
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	at := o.Epoch().Add(3 * 24 * time.Hour)
+	e, err := o.Prop(at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	near(t, "TEME r", e.ECI, Vect{-9060.47373569, 4658.70952502, 813.68673153}, 1e-6)
+	near(t, "TEME v", e.V, Vect{-2.232832783, -4.110453490, -3.157345433}, 1e-9)
+
+	// Vallado et al. (2006) give this J2000 state, which they
+	// computed with only the first four terms of the nutation series.
+	var (
+		j2000r = Vect{-9059.9413786, 4659.6972000, 813.9588875}
+		j2000v = Vect{-2.233348094, -4.110136162, -3.157394074}
+	)
+	r, v := temeToJ2000(at, e.ECI, e.V, EOP{}, 4)
+	near(t, "J2000 r (4 terms)", r, j2000r, 1e-6)
+	near(t, "J2000 v (4 terms)", v, j2000v, 1e-9)
+
+	// The rest of the series moves the state by about a meter.
+	r, v = TEMEToJ2000(at, e.ECI, e.V, EOP{})
+	near(t, "J2000 r", r, j2000r, 1.2e-3)
+	near(t, "J2000 v", v, j2000v, 1e-6)
+}
+
+func TestTEMEToGCRF(t *testing.T) {
+	// Vallado's Example 3-15 gives TOD, MOD, and (via the
+	// IAU-2006/2000A CIO-based transformation) GCRF states.
+	x := vallado0406
+	x.eop.DPsi, x.eop.DEps = -0.052195, -0.003875
+
+	r, _ := TEMEToTOD(x.t, x.r, x.v, x.eop)
+	near(t, "TOD", r, Vect{5094.51620300, 6127.36527840, 6380.34453270}, 1e-5)
+
+	r, _ = TEMEToMOD(x.t, x.r, x.v, x.eop)
+	near(t, "MOD", r, Vect{5094.02837450, 6127.87081640, 6380.24851640}, 2e-4)
+
+	// FK5 with the nutation corrections approximates GCRF.
+	gcrf := Vect{5102.508958, 6123.011401, 6378.136928}
+	r, v := TEMEToJ2000(x.t, x.r, x.v, x.eop)
+	near(t, "J2000", r, gcrf, 2e-4)
+	near(t, "J2000 v", v, Vect{-4.743220157, 0.790536497, 5.533755727}, 1e-6)
+
+	// TEMEToGCRF uses the corrections when it has them.
+	if r1, v1 := TEMEToGCRF(x.t, x.r, x.v, x.eop); r1 != r || v1 != v {
+		t.Fatal(r1, v1)
+	}
+
+	// Without them, the frame bias gets within a meter.
+	x.eop.DPsi, x.eop.DEps = 0, 0
+	r, _ = TEMEToGCRF(x.t, x.r, x.v, x.eop)
+	near(t, "GCRF", r, gcrf, 1e-3)
+}
+
+func TestFramesLeapSeconds(t *testing.T) {
+	x := vallado0406
+
+	// A table that's 8 seconds ahead of DefaultLeapSeconds in 2004.
+	l, err := ParseLeapSecondDat(strings.NewReader("41317.0    1  1 1972       40\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		tab    = &EOPTable{Leaps: l}
+		eop, _ = tab.At(x.t)
+		later  = x.t.Add(8 * time.Second)
+	)
+	if eop.Leaps != l {
+		t.Fatal(eop)
+	}
+	r, v := TEMEToJ2000(x.t, x.r, x.v, eop)
+	r8, v8 := TEMEToJ2000(later, x.r, x.v, EOP{})
+	near(t, "r", r, r8, 1e-9)
+	near(t, "v", v, v8, 1e-12)
+	if r0, _ := TEMEToJ2000(x.t, x.r, x.v, EOP{}); r0 == r {
+		t.Fatal(r0)
+	}
+
+	// The Observer's Sun uses the table too.
+	var (
+		o    = &Observer{EOP: tab}
+		got  = o.SunElevation(x.t)
+		want = (&Observer{}).Look(x.t, Ephemeris{ECI: SunPosition(later)}).El
+	)
+	if 1e-12 < math.Abs(got-want) {
+		t.Fatal(got, want)
+	}
+}
@@ -0,0 +1,92 @@
+package sgp4go
+
+import "math"
+
+// Ellipsoid is a reference ellipsoid for geodetic coordinates.
+type Ellipsoid struct {
+	// A is the equatorial radius in km.
+	A float64
+
+	// F is the flattening.
+	F float64
+}
+
+// WGS84Ellipsoid is the WGS-84 ellipsoid.
+var WGS84Ellipsoid = Ellipsoid{A: 6378.137, F: 1 / 298.257223563}
+
+// LatLonAlt is a geodetic position.
+type LatLonAlt struct {
+	// Lat and Lon are the geodetic latitude and longitude in
+	// degrees.  Longitude is in (-180,180].
+	Lat, Lon float64
+
+	// Alt is the height above the ellipsoid in km.
+	Alt float64
+}
+
+// ToLLA converts an ECEF position (km) to geodetic coordinates using
+// Heikkinen's closed-form solution, which has no iteration.
+//
+// For positions from the Earth's surface out to well beyond
+// geosynchronous altitude, the result round-trips through ToECEF()
+// to better than a micrometer.  Positions within about 40 km of the
+// Earth's center are not supported.
+func (e Ellipsoid) ToLLA(r Vect) LatLonAlt {
+	var (
+		a   = e.A
+		b   = a * (1 - e.F)
+		e2  = e.F * (2 - e.F)
+		ep2 = e2 / (1 - e2)
+
+		p2 = r.X*r.X + r.Y*r.Y
+		p  = math.Sqrt(p2)
+		z2 = r.Z * r.Z
+
+		f  = 54 * b * b * z2
+		g  = p2 + (1-e2)*z2 - e2*(a*a-b*b)
+		c  = e2 * e2 * f * p2 / (g * g * g)
+		s  = math.Cbrt(1 + c + math.Sqrt(c*c+2*c))
+		k  = s + 1 + 1/s
+		pp = f / (3 * k * k * g * g)
+		q  = math.Sqrt(1 + 2*e2*e2*pp)
+		r0 = -pp*e2*p/(1+q) + math.Sqrt(a*a/2*(1+1/q)-pp*(1-e2)*z2/(q*(1+q))-pp*p2/2)
+
+		d  = p - e2*r0
+		u  = math.Sqrt(d*d + z2)
+		v  = math.Sqrt(d*d + (1-e2)*z2)
+		z0 = b * b * r.Z / (a * v)
+	)
+
+	return LatLonAlt{
+		Lat: math.Atan2(r.Z+ep2*z0, p) * 180 / math.Pi,
+		Lon: math.Atan2(r.Y, r.X) * 180 / math.Pi,
+		Alt: u * (1 - b*b/(a*v)),
+	}
+}
+
+// ToECEF converts geodetic coordinates to an ECEF position (km).
+func (e Ellipsoid) ToECEF(p LatLonAlt) Vect {
+	var (
+		e2         = e.F * (2 - e.F)
+		slat, clat = math.Sincos(p.Lat * math.Pi / 180)
+		slon, clon = math.Sincos(p.Lon * math.Pi / 180)
+		n          = e.A / math.Sqrt(1-e2*slat*slat)
+	)
+	return Vect{
+		(n + p.Alt) * clat * clon,
+		(n + p.Alt) * clat * slon,
+		(n*(1-e2) + p.Alt) * slat,
+	}
+}
+
+// ECEFToLLA converts an ECEF position (km) to WGS-84 geodetic
+// coordinates.  See Ellipsoid.ToLLA().
+func ECEFToLLA(r Vect) LatLonAlt {
+	return WGS84Ellipsoid.ToLLA(r)
+}
+
+// LLAToECEF converts WGS-84 geodetic coordinates to an ECEF position
+// (km).
+func LLAToECEF(p LatLonAlt) Vect {
+	return WGS84Ellipsoid.ToECEF(p)
+}
@@ -0,0 +1,59 @@
+package sgp4go
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGeodetic(t *testing.T) {
+	// Vallado's Example 3-3: ECEF to geodetic.
+	p := ECEFToLLA(Vect{6524.834, 6862.875, 6448.296})
+	if 1e-6 < math.Abs(p.Lat-34.352496) || 1e-4 < math.Abs(p.Lon-46.4464) || 0.005 < math.Abs(p.Alt-5085.22) {
+		t.Fatal(p)
+	}
+
+	// On the equator and at the poles.
+	for _, tc := range []struct {
+		r Vect
+		p LatLonAlt
+	}{
+		{Vect{6378.137, 0, 0}, LatLonAlt{0, 0, 0}},
+		{Vect{0, -7000, 0}, LatLonAlt{0, -90, 7000 - 6378.137}},
+		{Vect{0, 0, 6356.7523142}, LatLonAlt{90, 0, 0}},
+		{Vect{0, 0, -7000}, LatLonAlt{-90, 0, 7000 - 6356.7523142}},
+	} {
+		p := ECEFToLLA(tc.r)
+		if 1e-9 < math.Abs(p.Lat-tc.p.Lat) || 1e-9 < math.Abs(p.Lon-tc.p.Lon) || 1e-6 < math.Abs(p.Alt-tc.p.Alt) {
+			t.Fatal(tc.r, p)
+		}
+	}
+
+	// Round trips.
+	for _, e := range []Ellipsoid{WGS84Ellipsoid, {A: 6378.135, F: 1 / 298.26}} {
+		for lat := -90.0; lat <= 90; lat += 7.5 {
+			for _, alt := range []float64{-0.5, 0, 0.3, 400, 20200, 35786, 100000} {
+				var (
+					want = LatLonAlt{lat, 123.4, alt}
+					r    = e.ToECEF(want)
+					got  = e.ToLLA(r)
+					back = e.ToECEF(got)
+				)
+				if d := math.Sqrt((back.X-r.X)*(back.X-r.X) + (back.Y-r.Y)*(back.Y-r.Y) + (back.Z-r.Z)*(back.Z-r.Z)); 1e-9 < d {
+					t.Fatal(want, got, d)
+				}
+				if 1e-9 < math.Abs(got.Lat-lat) || 1e-9 < math.Abs(got.Alt-alt) {
+					t.Fatal(want, got)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkECEFToLLA(b *testing.B) {
+	r := Vect{-1033.4793830, 7901.2952754, 6380.3565958}
+	for i := 0; i < b.N; i++ {
+		if p := ECEFToLLA(r); p.Alt < 0 {
+			b.Fatal(p)
+		}
+	}
+}
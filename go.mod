@@ -2,7 +2,4 @@ module github.com/morphism/sgp4go
 
 go 1.14
 
-require (
-	github.com/elliotchance/c2go v0.26.7
-	github.com/jsmorph/go-satellite v0.0.0-20200209185444-f2e743f52cab
-)
+require github.com/elliotchance/c2go v0.26.7
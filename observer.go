@@ -0,0 +1,144 @@
+package sgp4go
+
+import (
+	"math"
+	"time"
+)
+
+// Observer is a ground station at a WGS-84 geodetic position.
+type Observer struct {
+	// Position is the observer's location (with Alt in km).
+	Position LatLonAlt
+
+	// EOP (if not nil) provides Earth orientation parameters.
+	EOP *EOPTable
+
+	// Refraction enables the correction of elevations for
+	// atmospheric refraction.
+	Refraction bool
+
+	// Pressure (millibars) and Temperature (Celsius) adjust the
+	// refraction.  If both are zero, they're taken to be 1010 mbar
+	// and 10 C.
+	Pressure, Temperature float64
+}
+
+// Look is the view of a satellite from an Observer.
+type Look struct {
+	// Az is the azimuth in degrees east of north, in [0,360).
+	Az float64
+
+	// El is the elevation in degrees.  It's the apparent elevation
+	// if the Observer corrects for refraction.
+	El float64
+
+	// Range is the distance in km.
+	Range float64
+
+	// RangeRate is the rate of change of Range in km/sec.
+	RangeRate float64
+}
+
+// Look returns the view of the given ephemeris (from TLE.Prop()) at
+// the given time.
+//
+// The satellite's TEME state is converted to ECEF and then to the
+// observer's local East-North-Up frame.
+func (o *Observer) Look(t time.Time, e Ephemeris) Look {
+	var (
+		eop, _ = o.EOP.At(t)
+		r, v   = TEMEToECEF(t, e.ECI, e.V, eop)
+		site   = LLAToECEF(o.Position)
+		rho    = Vect{r.X - site.X, r.Y - site.Y, r.Z - site.Z}
+
+		slat, clat = math.Sincos(o.Position.Lat * math.Pi / 180)
+		slon, clon = math.Sincos(o.Position.Lon * math.Pi / 180)
+
+		east  = -slon*rho.X + clon*rho.Y
+		north = -slat*clon*rho.X - slat*slon*rho.Y + clat*rho.Z
+		up    = clat*clon*rho.X + clat*slon*rho.Y + slat*rho.Z
+
+		rng = math.Sqrt(rho.X*rho.X + rho.Y*rho.Y + rho.Z*rho.Z)
+		az  = math.Atan2(east, north) * 180 / math.Pi
+		el  = math.Asin(up/rng) * 180 / math.Pi
+	)
+
+	if az < 0 {
+		az += 360
+	}
+	if o.Refraction {
+		el += o.refraction(el)
+	}
+
+	return Look{
+		Az:        az,
+		El:        el,
+		Range:     rng,
+		RangeRate: (rho.X*v.X + rho.Y*v.Y + rho.Z*v.Z) / rng,
+	}
+}
+
+// LookAt propagates the TLE to the given time and returns the view.
+func (o *Observer) LookAt(tle *TLE, t time.Time) (Look, error) {
+	var e Ephemeris
+	if err := tle.PropInto(t, &e); err != nil {
+		return Look{}, err
+	}
+	return o.Look(t, e), nil
+}
+
+// refraction returns the refraction in degrees for the given true
+// elevation using Saemundsson's formula, which is good to about 0.1
+// arcminute above a few degrees.  Below -1 degree, the refraction is
+// zero.
+func (o *Observer) refraction(el float64) float64 {
+	if el < -1 {
+		return 0
+	}
+	var (
+		p = o.Pressure
+		t = o.Temperature
+	)
+	if p == 0 && t == 0 {
+		p, t = 1010, 10
+	}
+	// The small constant makes the refraction zero at the zenith.
+	arcmin := 1.02/math.Tan((el+10.3/(el+5.11))*math.Pi/180) + 0.0019279
+	return arcmin / 60 * (p / 1010) * (283 / (273 + t))
+}
+
+// SunElevation returns the Sun's elevation in degrees.
+func (o *Observer) SunElevation(t time.Time) float64 {
+	eop, _ := o.EOP.At(t)
+	return o.Look(t, Ephemeris{ECI: sunPosition(t, eop.Leaps)}).El
+}
+
+// Magnitude estimates the apparent visual magnitude of a satellite
+// with the given standard magnitude, which is its magnitude at a
+// range of 1000 km when half illuminated (at a phase angle of 90
+// degrees).
+//
+// The satellite is modeled as a diffuse sphere, so the magnitude is
+//
+//	std - 15 + 5 log10(range) - 2.5 log10(sin(phase) + (pi-phase) cos(phase))
+//
+// and it's dimmer in the penumbra.  The result is +Inf if the
+// satellite is in the umbra.
+func (o *Observer) Magnitude(t time.Time, e Ephemeris, std float64) float64 {
+	var (
+		eop, _ = o.EOP.At(t)
+		site   = LLAToECEF(o.Position)
+		r, _   = ECEFToTEME(t, site, Vect{}, eop)
+		sun    = sunPosition(t, eop.Leaps)
+		toSun  = Vect{sun.X - e.ECI.X, sun.Y - e.ECI.Y, sun.Z - e.ECI.Z}
+		toObs  = Vect{r.X - e.ECI.X, r.Y - e.ECI.Y, r.Z - e.ECI.Z}
+		phase  = math.Atan2(norm(cross(toSun, toObs)), dot(toSun, toObs))
+	)
+	return magnitude(std, norm(toObs), phase) - 2.5*math.Log10(Illumination(e.ECI, sun))
+}
+
+// magnitude returns the magnitude of a diffuse sphere at the given
+// range (km) and phase angle (radians).
+func magnitude(std, rng, phase float64) float64 {
+	return std - 15 + 5*math.Log10(rng) - 2.5*math.Log10(math.Sin(phase)+(math.Pi-phase)*math.Cos(phase))
+}
@@ -0,0 +1,112 @@
+package sgp4go
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// lookAt returns the view of a stationary point at the given
+// position.
+func lookAt(o *Observer, at time.Time, p LatLonAlt) Look {
+	r, v := ECEFToTEME(at, LLAToECEF(p), Vect{}, EOP{})
+	return o.Look(at, Ephemeris{ECI: r, V: v})
+}
+
+func TestObserverLook(t *testing.T) {
+	var (
+		at = time.Date(2020, 12, 14, 12, 0, 0, 0, time.UTC)
+		o  = &Observer{Position: LatLonAlt{Lat: 40, Lon: -75, Alt: 0.1}}
+	)
+
+	l := lookAt(o, at, LatLonAlt{Lat: 40, Lon: -75, Alt: 500.1})
+	if l.El < 89.999 || 1e-6 < math.Abs(l.Range-500) || 1e-9 < math.Abs(l.RangeRate) {
+		t.Fatal(l)
+	}
+
+	o.Position = LatLonAlt{}
+	for _, tc := range []struct {
+		p  LatLonAlt
+		az float64
+	}{
+		{LatLonAlt{Lat: 10, Alt: 500}, 0},
+		{LatLonAlt{Lon: 10, Alt: 500}, 90},
+		{LatLonAlt{Lat: -10, Alt: 500}, 180},
+		{LatLonAlt{Lon: -10, Alt: 500}, 270},
+	} {
+		l := lookAt(o, at, tc.p)
+		if d := math.Mod(l.Az-tc.az+540, 360) - 180; 1e-9 < math.Abs(d) || l.El < 0 || l.Az < 0 || 360 <= l.Az {
+			t.Fatal(tc.p, l)
+		}
+	}
+
+	// Below the horizon.
+	if l := lookAt(o, at, LatLonAlt{Lon: 90, Alt: 500}); 0 <= l.El {
+		t.Fatal(l)
+	}
+}
+
+func TestObserverRangeRate(t *testing.T) {
+	var (
+		tle = getExample(t)
+		o   = &Observer{Position: LatLonAlt{Lat: 30, Lon: 180, Alt: 0}}
+		at  = time.Date(2020, 12, 14, 7, 0, 0, 0, time.UTC)
+		dt  = time.Second
+	)
+
+	l, err := o.LookAt(tle, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l0, _ := o.LookAt(tle, at.Add(-dt))
+	l1, _ := o.LookAt(tle, at.Add(dt))
+	if rr := (l1.Range - l0.Range) / (2 * dt.Seconds()); 1e-3 < math.Abs(rr-l.RangeRate) {
+		t.Fatal(rr, l.RangeRate)
+	}
+}
+
+func TestObserverRefraction(t *testing.T) {
+	var (
+		at = time.Date(2020, 12, 14, 12, 0, 0, 0, time.UTC)
+		o  = &Observer{}
+		p  = LatLonAlt{Lon: 20, Alt: 1000}
+	)
+
+	l := lookAt(o, at, p)
+	o.Refraction = true
+	lr := lookAt(o, at, p)
+	if d := lr.El - l.El; d < 0.05 || 0.1 < d || lr.Az != l.Az || lr.Range != l.Range {
+		t.Fatal(l, lr)
+	}
+
+	// About half a degree at the horizon and nothing overhead.
+	if r := o.refraction(0); math.Abs(r-0.48) > 0.01 {
+		t.Fatal(r)
+	}
+	if r := o.refraction(90); 1e-5 < math.Abs(r) {
+		t.Fatal(r)
+	}
+	if r := o.refraction(-2); r != 0 {
+		t.Fatal(r)
+	}
+
+	// Colder and denser air refracts more.
+	o.Pressure, o.Temperature = 1030, -20
+	if r := o.refraction(0); r < 0.5 {
+		t.Fatal(r)
+	}
+}
+
+func TestSunElevation(t *testing.T) {
+	var (
+		o    = &Observer{}
+		noon = time.Date(2020, 3, 20, 12, 7, 0, 0, time.UTC)
+	)
+	// Near the equinox, the Sun is overhead at noon on the equator.
+	if el := o.SunElevation(noon); el < 89.5 {
+		t.Fatal(el)
+	}
+	if el := o.SunElevation(noon.Add(12 * time.Hour)); -89.5 < el {
+		t.Fatal(el)
+	}
+}
@@ -0,0 +1,368 @@
+package sgp4go
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OMM is a CCSDS Orbit Mean-elements Message (OMM) with SGP4 mean
+// elements, which is how Space-Track and CelesTrak publish general
+// perturbations (GP) data.
+//
+// Angles are in degrees, and mean motion is in revolutions per day.
+// As in a TLE, MeanMotionDot and MeanMotionDDot are the first
+// derivative of mean motion divided by 2 and the second derivative
+// divided by 6.
+type OMM struct {
+	Originator        string
+	CreationDate      time.Time
+	ObjectName        string
+	ObjectID          string
+	CenterName        string
+	RefFrame          string
+	TimeSystem        string
+	MeanElementTheory string
+
+	Epoch           time.Time
+	MeanMotion      float64
+	Eccentricity    float64
+	Inclination     float64
+	RAOfAscNode     float64
+	ArgOfPericenter float64
+	MeanAnomaly     float64
+
+	EphemerisType      int
+	ClassificationType string
+	NoradCatID         int64
+	ElementSetNo       int64
+	RevAtEpoch         int64
+	BStar              float64
+	MeanMotionDot      float64
+	MeanMotionDDot     float64
+}
+
+// ommRequired are the keywords that must be present.
+var ommRequired = []string{
+	"EPOCH",
+	"MEAN_MOTION",
+	"ECCENTRICITY",
+	"INCLINATION",
+	"RA_OF_ASC_NODE",
+	"ARG_OF_PERICENTER",
+	"MEAN_ANOMALY",
+}
+
+// ommFields collects OMM keyword values.
+type ommFields map[string]string
+
+// ParseOMMTime parses OMM (CCSDS) times like
+// "2020-12-14T06:45:49.070880" and "2020-349T06:45:49.070880" as UTC.
+// A trailing "Z" is accepted.
+func ParseOMMTime(s string) (time.Time, error) {
+	s = strings.TrimSuffix(strings.TrimSpace(s), "Z")
+	layout := "2006-01-02T15:04:05"
+	if i := strings.IndexByte(s, 'T'); i == 8 {
+		layout = "2006-002T15:04:05"
+	}
+	return time.ParseInLocation(layout, s, time.UTC)
+}
+
+// omm converts the keyword values to an OMM.
+func (fs ommFields) omm() (*OMM, error) {
+	for _, k := range ommRequired {
+		if _, have := fs[k]; !have {
+			return nil, fmt.Errorf("OMM missing %s", k)
+		}
+	}
+
+	var (
+		o   = &OMM{}
+		err error
+
+		str = func(k string, p *string) {
+			*p = fs[k]
+		}
+		t = func(k string, p *time.Time) {
+			if s, have := fs[k]; have && err == nil {
+				if *p, err = ParseOMMTime(s); err != nil {
+					err = fmt.Errorf("OMM %s %q: %w", k, s, err)
+				}
+			}
+		}
+		f = func(k string, p *float64) {
+			if s, have := fs[k]; have && err == nil {
+				if *p, err = strconv.ParseFloat(s, 64); err != nil {
+					err = fmt.Errorf("OMM %s %q: %w", k, s, err)
+				}
+			}
+		}
+		i = func(k string, p *int64) {
+			if s, have := fs[k]; have && err == nil {
+				if *p, err = strconv.ParseInt(s, 10, 64); err != nil {
+					err = fmt.Errorf("OMM %s %q: %w", k, s, err)
+				}
+			}
+		}
+		ephtype int64
+	)
+
+	str("ORIGINATOR", &o.Originator)
+	t("CREATION_DATE", &o.CreationDate)
+	str("OBJECT_NAME", &o.ObjectName)
+	str("OBJECT_ID", &o.ObjectID)
+	str("CENTER_NAME", &o.CenterName)
+	str("REF_FRAME", &o.RefFrame)
+	str("TIME_SYSTEM", &o.TimeSystem)
+	str("MEAN_ELEMENT_THEORY", &o.MeanElementTheory)
+
+	t("EPOCH", &o.Epoch)
+	f("MEAN_MOTION", &o.MeanMotion)
+	f("ECCENTRICITY", &o.Eccentricity)
+	f("INCLINATION", &o.Inclination)
+	f("RA_OF_ASC_NODE", &o.RAOfAscNode)
+	f("ARG_OF_PERICENTER", &o.ArgOfPericenter)
+	f("MEAN_ANOMALY", &o.MeanAnomaly)
+
+	i("EPHEMERIS_TYPE", &ephtype)
+	str("CLASSIFICATION_TYPE", &o.ClassificationType)
+	i("NORAD_CAT_ID", &o.NoradCatID)
+	i("ELEMENT_SET_NO", &o.ElementSetNo)
+	i("REV_AT_EPOCH", &o.RevAtEpoch)
+	f("BSTAR", &o.BStar)
+	f("MEAN_MOTION_DOT", &o.MeanMotionDot)
+	f("MEAN_MOTION_DDOT", &o.MeanMotionDDot)
+	o.EphemerisType = int(ephtype)
+
+	if err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// set records a keyword value.  Units (like "[rev/day]") are
+// removed, and empty values are ignored.
+func (fs ommFields) set(k, v string) {
+	v = strings.TrimSpace(v)
+	if i := strings.IndexByte(v, '['); 0 <= i && strings.HasSuffix(v, "]") {
+		v = strings.TrimSpace(v[:i])
+	}
+	k = strings.ToUpper(strings.TrimSpace(k))
+	if k == "" || v == "" || k == "COMMENT" {
+		return
+	}
+	fs[k] = v
+}
+
+// ParseOMMKVN parses an OMM in Keyword = Value Notation (KVN).
+func ParseOMMKVN(r io.Reader) (*OMM, error) {
+	var (
+		fs = make(ommFields)
+		s  = bufio.NewScanner(r)
+		n  int
+	)
+	for s.Scan() {
+		n++
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "COMMENT") {
+			continue
+		}
+		i := strings.IndexByte(line, '=')
+		if i < 0 {
+			return nil, fmt.Errorf("OMM KVN line %d: no '=' in %q", n, line)
+		}
+		fs.set(line[:i], line[i+1:])
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return fs.omm()
+}
+
+// ParseOMMXML parses the OMMs in a CCSDS NDM/XML document.
+func ParseOMMXML(r io.Reader) ([]*OMM, error) {
+	var (
+		d    = xml.NewDecoder(r)
+		omms []*OMM
+		fs   ommFields
+		text strings.Builder
+	)
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch x := tok.(type) {
+		case xml.StartElement:
+			if x.Name.Local == "omm" {
+				fs = make(ommFields)
+			}
+			text.Reset()
+		case xml.CharData:
+			text.Write(x)
+		case xml.EndElement:
+			if x.Name.Local == "omm" {
+				o, err := fs.omm()
+				if err != nil {
+					return nil, err
+				}
+				omms = append(omms, o)
+				fs = nil
+			} else if fs != nil {
+				fs.set(x.Name.Local, text.String())
+			}
+			text.Reset()
+		}
+	}
+	if len(omms) == 0 {
+		return nil, errors.New("no omm elements in XML")
+	}
+	return omms, nil
+}
+
+// ParseOMMJSON parses OMMs in JSON as published by CelesTrak and
+// Space-Track.  The input can be a single object or an array of
+// objects, and numbers can be given as JSON strings.
+func ParseOMMJSON(r io.Reader) ([]*OMM, error) {
+	var (
+		d = json.NewDecoder(r)
+		x interface{}
+	)
+	// Avoid any loss of precision.
+	d.UseNumber()
+	if err := d.Decode(&x); err != nil {
+		return nil, err
+	}
+
+	var objs []interface{}
+	switch vv := x.(type) {
+	case []interface{}:
+		objs = vv
+	case map[string]interface{}:
+		objs = []interface{}{vv}
+	default:
+		return nil, fmt.Errorf("unexpected OMM JSON %T", x)
+	}
+
+	omms := make([]*OMM, 0, len(objs))
+	for n, x := range objs {
+		obj, is := x.(map[string]interface{})
+		if !is {
+			return nil, fmt.Errorf("OMM JSON element %d: unexpected %T", n, x)
+		}
+		fs := make(ommFields)
+		for k, v := range obj {
+			switch vv := v.(type) {
+			case string:
+				fs.set(k, vv)
+			case json.Number:
+				fs.set(k, vv.String())
+			}
+		}
+		o, err := fs.omm()
+		if err != nil {
+			return nil, fmt.Errorf("OMM JSON element %d: %w", n, err)
+		}
+		omms = append(omms, o)
+	}
+	return omms, nil
+}
+
+// ParseOMMCSV parses OMMs in CSV as published by CelesTrak and
+// Space-Track.  The first record is a header with OMM keywords.
+func ParseOMMCSV(r io.Reader) ([]*OMM, error) {
+	rs, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rs) == 0 {
+		return nil, errors.New("no header in OMM CSV")
+	}
+
+	var (
+		header = rs[0]
+		omms   = make([]*OMM, 0, len(rs)-1)
+	)
+	for n, rec := range rs[1:] {
+		fs := make(ommFields)
+		for i, v := range rec {
+			fs.set(header[i], v)
+		}
+		o, err := fs.omm()
+		if err != nil {
+			return nil, fmt.Errorf("OMM CSV record %d: %w", n+1, err)
+		}
+		omms = append(omms, o)
+	}
+	return omms, nil
+}
+
+// intlDesignator converts an OMM OBJECT_ID (like "1998-067A") to a
+// TLE international designator (like "98067A").
+func intlDesignator(id string) string {
+	if len(id) < 9 || id[4] != '-' {
+		return id
+	}
+	return id[2:4] + id[5:]
+}
+
+// NewTLEFromOMM constructs a new TLE (which can be propagated) from
+// the mean elements in an OMM, keeping their full precision.
+//
+// Since no TLE text is involved, Lines() returns empty strings.  See
+// Format().  Options that concern TLE text (like WithChecksum()) have
+// no effect.
+func NewTLEFromOMM(o *OMM, opts ...Option) (*TLE, error) {
+	if o.MeanElementTheory != "" && !strings.Contains(strings.ToUpper(o.MeanElementTheory), "SGP4") {
+		return nil, fmt.Errorf("OMM MEAN_ELEMENT_THEORY %q is not SGP4", o.MeanElementTheory)
+	}
+	if o.TimeSystem != "" && o.TimeSystem != "UTC" {
+		return nil, fmt.Errorf("OMM TIME_SYSTEM %q is not UTC", o.TimeSystem)
+	}
+
+	opt, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	tle := &TLE{}
+	opt.apply(tle)
+
+	tle.objectNum = o.NoradCatID
+	tle.Rec.classification = 'U'
+	if o.ClassificationType != "" {
+		tle.Rec.classification = o.ClassificationType[0]
+	}
+	copy(tle.intlid[:8], intlDesignator(o.ObjectID))
+	tle.Rec.ephtype = int64(o.EphemerisType)
+	tle.elnum = o.ElementSetNo
+	tle.revnum = o.RevAtEpoch
+
+	tle.ndot = o.MeanMotionDot
+	tle.nddot = o.MeanMotionDDot
+	tle.bstar = o.BStar
+	tle.incDeg = o.Inclination
+	tle.raanDeg = o.RAOfAscNode
+	tle.ecc = o.Eccentricity
+	tle.argpDeg = o.ArgOfPericenter
+	tle.maDeg = o.MeanAnomaly
+	tle.n = o.MeanMotion
+	setEpoch(tle, o.Epoch)
+
+	setValsToRec(tle, &tle.Rec)
+	if err := tle.initErr(); err != nil {
+		return nil, err
+	}
+
+	return tle, nil
+}
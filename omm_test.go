@@ -0,0 +1,270 @@
+package sgp4go
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// issKVN, etc. are the ISS TLE (see ISS) as OMMs.
+var issKVN = `CCSDS_OMM_VERS = 2.0
+COMMENT This is a comment.
+CREATION_DATE = 2020-12-14T12:00:00
+ORIGINATOR = 18 SPCS
+OBJECT_NAME = ISS (ZARYA)
+OBJECT_ID = 1998-067A
+CENTER_NAME = EARTH
+REF_FRAME = TEME
+TIME_SYSTEM = UTC
+MEAN_ELEMENT_THEORY = SGP4
+EPOCH = 2020-12-14T06:45:49.070880
+MEAN_MOTION = 15.49184106 [rev/day]
+ECCENTRICITY = .0001731
+INCLINATION = 51.6443 [deg]
+RA_OF_ASC_NODE = 177.3570 [deg]
+ARG_OF_PERICENTER = 128.2351 [deg]
+MEAN_ANOMALY = 43.6939 [deg]
+EPHEMERIS_TYPE = 0
+CLASSIFICATION_TYPE = U
+NORAD_CAT_ID = 25544
+ELEMENT_SET_NO = 999
+REV_AT_EPOCH = 25993
+BSTAR = .27992E-4 [1/ER]
+MEAN_MOTION_DOT = .00001103 [rev/day**2]
+MEAN_MOTION_DDOT = 0 [rev/day**3]
+`
+
+var issXML = `<?xml version="1.0" encoding="UTF-8"?>
+<ndm xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+<omm id="CCSDS_OMM_VERS" version="2.0">
+<header><CREATION_DATE/><ORIGINATOR/></header>
+<body><segment>
+<metadata>
+<OBJECT_NAME>ISS (ZARYA)</OBJECT_NAME>
+<OBJECT_ID>1998-067A</OBJECT_ID>
+<CENTER_NAME>EARTH</CENTER_NAME>
+<REF_FRAME>TEME</REF_FRAME>
+<TIME_SYSTEM>UTC</TIME_SYSTEM>
+<MEAN_ELEMENT_THEORY>SGP4</MEAN_ELEMENT_THEORY>
+</metadata>
+<data>
+<meanElements>
+<EPOCH>2020-12-14T06:45:49.070880</EPOCH>
+<MEAN_MOTION>15.49184106</MEAN_MOTION>
+<ECCENTRICITY>.0001731</ECCENTRICITY>
+<INCLINATION>51.6443</INCLINATION>
+<RA_OF_ASC_NODE>177.3570</RA_OF_ASC_NODE>
+<ARG_OF_PERICENTER>128.2351</ARG_OF_PERICENTER>
+<MEAN_ANOMALY>43.6939</MEAN_ANOMALY>
+</meanElements>
+<tleParameters>
+<EPHEMERIS_TYPE>0</EPHEMERIS_TYPE>
+<CLASSIFICATION_TYPE>U</CLASSIFICATION_TYPE>
+<NORAD_CAT_ID>25544</NORAD_CAT_ID>
+<ELEMENT_SET_NO>999</ELEMENT_SET_NO>
+<REV_AT_EPOCH>25993</REV_AT_EPOCH>
+<BSTAR>.27992E-4</BSTAR>
+<MEAN_MOTION_DOT>.00001103</MEAN_MOTION_DOT>
+<MEAN_MOTION_DDOT>0</MEAN_MOTION_DDOT>
+</tleParameters>
+</data>
+</segment></body>
+</omm>
+</ndm>
+`
+
+var issJSON = `[{"OBJECT_NAME":"ISS (ZARYA)","OBJECT_ID":"1998-067A","EPOCH":"2020-12-14T06:45:49.070880","MEAN_MOTION":15.49184106,"ECCENTRICITY":0.0001731,"INCLINATION":51.6443,"RA_OF_ASC_NODE":177.357,"ARG_OF_PERICENTER":128.2351,"MEAN_ANOMALY":43.6939,"EPHEMERIS_TYPE":0,"CLASSIFICATION_TYPE":"U","NORAD_CAT_ID":25544,"ELEMENT_SET_NO":999,"REV_AT_EPOCH":25993,"BSTAR":2.7992e-5,"MEAN_MOTION_DOT":1.103e-5,"MEAN_MOTION_DDOT":0}]`
+
+// issSpaceTrackJSON has numbers as strings.
+var issSpaceTrackJSON = `{"OBJECT_NAME":"ISS (ZARYA)","OBJECT_ID":"1998-067A","EPOCH":"2020-12-14T06:45:49.070880","MEAN_MOTION":"15.49184106","ECCENTRICITY":"0.00017310","INCLINATION":"51.6443","RA_OF_ASC_NODE":"177.3570","ARG_OF_PERICENTER":"128.2351","MEAN_ANOMALY":"43.6939","EPHEMERIS_TYPE":"0","CLASSIFICATION_TYPE":"U","NORAD_CAT_ID":"25544","ELEMENT_SET_NO":"999","REV_AT_EPOCH":"25993","BSTAR":"0.000027992000","MEAN_MOTION_DOT":"0.00001103","MEAN_MOTION_DDOT":"0.0000000000000","DECAY_DATE":null}`
+
+var issCSV = `OBJECT_NAME,OBJECT_ID,EPOCH,MEAN_MOTION,ECCENTRICITY,INCLINATION,RA_OF_ASC_NODE,ARG_OF_PERICENTER,MEAN_ANOMALY,EPHEMERIS_TYPE,CLASSIFICATION_TYPE,NORAD_CAT_ID,ELEMENT_SET_NO,REV_AT_EPOCH,BSTAR,MEAN_MOTION_DOT,MEAN_MOTION_DDOT
+ISS (ZARYA),1998-067A,2020-12-14T06:45:49.070880,15.49184106,.0001731,51.6443,177.3570,128.2351,43.6939,0,U,25544,999,25993,.27992e-4,.00001103,0
+`
+
+func TestOMM(t *testing.T) {
+	var (
+		want  = getExample(t)
+		one   = func(o *OMM, err error) ([]*OMM, error) { return []*OMM{o}, err }
+		then  = time.Date(2020, 12, 15, 0, 0, 0, 0, time.UTC)
+		e0, _ = want.Prop(then)
+	)
+
+	for name, parse := range map[string]func() ([]*OMM, error){
+		"kvn":        func() ([]*OMM, error) { return one(ParseOMMKVN(strings.NewReader(issKVN))) },
+		"xml":        func() ([]*OMM, error) { return ParseOMMXML(strings.NewReader(issXML)) },
+		"json":       func() ([]*OMM, error) { return ParseOMMJSON(strings.NewReader(issJSON)) },
+		"spacetrack": func() ([]*OMM, error) { return ParseOMMJSON(strings.NewReader(issSpaceTrackJSON)) },
+		"csv":        func() ([]*OMM, error) { return ParseOMMCSV(strings.NewReader(issCSV)) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			omms, err := parse()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(omms) != 1 {
+				t.Fatal(len(omms))
+			}
+			o := omms[0]
+			if o.ObjectName != "ISS (ZARYA)" || o.NoradCatID != 25544 {
+				t.Fatal(o)
+			}
+
+			tle, err := NewTLEFromOMM(o)
+			if err != nil {
+				t.Fatal(err)
+			}
+			line1, line2, err := tle.Format()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want1, want2 := want.Lines(); line1 != want1 || line2 != want2 {
+				t.Fatalf("\n%s\n%s", line1, line2)
+			}
+
+			e1, err := tle.Prop(then)
+			if err != nil {
+				t.Fatal(err)
+			}
+			d := math.Hypot(math.Hypot(e1.ECI.X-e0.ECI.X, e1.ECI.Y-e0.ECI.Y), e1.ECI.Z-e0.ECI.Z)
+			if 1e-6 < d {
+				t.Fatal(d)
+			}
+		})
+	}
+}
+
+func TestOMMErrors(t *testing.T) {
+	for name, kvn := range map[string]string{
+		"missing": strings.Replace(issKVN, "MEAN_MOTION =", "MEAN_MOTION_X =", 1),
+		"number":  strings.Replace(issKVN, "51.6443", "51.6.443", 1),
+		"epoch":   strings.Replace(issKVN, "2020-12-14T06", "2020-12-14 06", 1),
+	} {
+		if _, err := ParseOMMKVN(strings.NewReader(kvn)); err == nil {
+			t.Fatal(name)
+		}
+	}
+
+	o, err := ParseOMMKVN(strings.NewReader(strings.Replace(issKVN, "= SGP4", "= DSST", 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewTLEFromOMM(o); err == nil {
+		t.Fatal("accepted DSST")
+	}
+}
+
+func TestWriteOMM(t *testing.T) {
+	var (
+		tle = getExample(t)
+		o   = tle.OMM()
+	)
+	o.ObjectName = "ISS (ZARYA)"
+	o.Originator = "sgp4go"
+
+	if o.ObjectID != "1998-067A" {
+		t.Fatal(o.ObjectID)
+	}
+	d := o.Epoch.Sub(time.Date(2020, 12, 14, 6, 45, 49, 70880000, time.UTC))
+	if d < -time.Microsecond || time.Microsecond < d {
+		t.Fatal(o.Epoch)
+	}
+
+	var (
+		kvn, xml, js strings.Builder
+		omms         = []*OMM{o, o}
+	)
+	if err := WriteOMMKVN(&kvn, o); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteOMMXML(&xml, omms); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteOMMJSON(&js, omms); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(kvn.String(), "\nMEAN_ELEMENT_THEORY = SGP4\n") {
+		t.Fatal(kvn.String())
+	}
+
+	for name, parse := range map[string]func() ([]*OMM, error){
+		"kvn": func() ([]*OMM, error) {
+			o, err := ParseOMMKVN(strings.NewReader(kvn.String()))
+			return []*OMM{o}, err
+		},
+		"xml":  func() ([]*OMM, error) { return ParseOMMXML(strings.NewReader(xml.String())) },
+		"json": func() ([]*OMM, error) { return ParseOMMJSON(strings.NewReader(js.String())) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := parse()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, g := range got {
+				if g.ObjectName != o.ObjectName || g.Originator != o.Originator {
+					t.Fatal(g)
+				}
+				y, err := NewTLEFromOMM(g)
+				if err != nil {
+					t.Fatal(err)
+				}
+				line1, line2, err := y.Format()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if want1, want2 := tle.Lines(); line1 != want1 || line2 != want2 {
+					t.Fatalf("\n%s\n%s", line1, line2)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteOMMPrecision(t *testing.T) {
+	o := getExample(t).OMM()
+	o.MeanMotion = math.Nextafter(o.MeanMotion, 16)
+	o.Epoch = o.Epoch.Add(123 * time.Nanosecond)
+
+	var kvn, xml, js strings.Builder
+	if err := WriteOMMKVN(&kvn, o); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteOMMXML(&xml, []*OMM{o}); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteOMMJSON(&js, []*OMM{o}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ParseOMMKVN(strings.NewReader(kvn.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	omms := []*OMM{got}
+	for _, parse := range []func() ([]*OMM, error){
+		func() ([]*OMM, error) { return ParseOMMXML(strings.NewReader(xml.String())) },
+		func() ([]*OMM, error) { return ParseOMMJSON(strings.NewReader(js.String())) },
+	} {
+		got, err := parse()
+		if err != nil || len(got) != 1 {
+			t.Fatal(got, err)
+		}
+		omms = append(omms, got[0])
+	}
+	for _, got := range omms {
+		if got.MeanMotion != o.MeanMotion || !got.Epoch.Equal(o.Epoch) {
+			t.Fatal(got.MeanMotion, got.Epoch)
+		}
+	}
+
+	// JSON (and the other formats) can't represent NaN or infinities.
+	for _, x := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		o.BStar = x
+		if err := WriteOMMJSON(&js, []*OMM{o}); err == nil {
+			t.Fatal(x)
+		}
+		if err := WriteOMMKVN(&kvn, o); err == nil {
+			t.Fatal(x)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package sgp4go
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OMM returns the TLE's current values as an OMM.
+//
+// The TLE does not know the originator, creation date, or object
+// name, so the caller can provide them in the result.
+func (tle *TLE) OMM() *OMM {
+	class := ""
+	if c := tle.Rec.classification; c != 0 && c != ' ' {
+		class = string(c)
+	}
+	return &OMM{
+		ObjectID:          objectID(string(tle.intlid[:8])),
+		CenterName:        "EARTH",
+		RefFrame:          "TEME",
+		TimeSystem:        "UTC",
+		MeanElementTheory: "SGP4",
+
+		Epoch:           tle.Epoch(),
+		MeanMotion:      tle.n,
+		Eccentricity:    tle.ecc,
+		Inclination:     tle.incDeg,
+		RAOfAscNode:     tle.raanDeg,
+		ArgOfPericenter: tle.argpDeg,
+		MeanAnomaly:     tle.maDeg,
+
+		EphemerisType:      int(tle.Rec.ephtype),
+		ClassificationType: class,
+		NoradCatID:         tle.objectNum,
+		ElementSetNo:       tle.elnum,
+		RevAtEpoch:         tle.revnum,
+		BStar:              tle.bstar,
+		MeanMotionDot:      tle.ndot,
+		MeanMotionDDot:     tle.nddot,
+	}
+}
+
+// objectID converts a TLE international designator (like "98067A")
+// to an OMM OBJECT_ID (like "1998-067A").
+func objectID(intlid string) string {
+	intlid = strings.TrimSpace(strings.Trim(intlid, "\x00"))
+	if len(intlid) < 6 || checkDigits(intlid[0:5]) != nil {
+		return intlid
+	}
+	yr, _ := strconv.ParseInt(intlid[0:2], 10, 64)
+	return fmt.Sprintf("%d-%s", fullYear(yr), intlid[2:])
+}
+
+// ommTimeLayout is the layout for times in written OMMs, which keeps
+// all of a time.Time's nanoseconds.
+const ommTimeLayout = "2006-01-02T15:04:05.000000000"
+
+// ommPair is a keyword and its (rendered) value.
+type ommPair struct {
+	// section is the XML element that contains this keyword.
+	section string
+	key     string
+	value   string
+	numeric bool
+}
+
+// pairs returns the OMM's keyword values in the order given by CCSDS
+// 502.0-B-2.
+//
+// Numbers are written with the fewest digits that parse to the same
+// float64.  It's an error if a number is NaN or infinite.
+func (o *OMM) pairs() ([]ommPair, error) {
+	var (
+		f = func(x float64) string {
+			return strconv.FormatFloat(x, 'g', -1, 64)
+		}
+		i = func(x int64) string {
+			return strconv.FormatInt(x, 10)
+		}
+		created = o.CreationDate
+	)
+	if created.IsZero() {
+		created = time.Now()
+	}
+
+	ps := []ommPair{
+		{"header", "CREATION_DATE", created.UTC().Format(ommTimeLayout), false},
+		{"header", "ORIGINATOR", o.Originator, false},
+
+		{"metadata", "OBJECT_NAME", o.ObjectName, false},
+		{"metadata", "OBJECT_ID", o.ObjectID, false},
+		{"metadata", "CENTER_NAME", o.CenterName, false},
+		{"metadata", "REF_FRAME", o.RefFrame, false},
+		{"metadata", "TIME_SYSTEM", o.TimeSystem, false},
+		{"metadata", "MEAN_ELEMENT_THEORY", o.MeanElementTheory, false},
+
+		{"meanElements", "EPOCH", o.Epoch.UTC().Format(ommTimeLayout), false},
+		{"meanElements", "MEAN_MOTION", f(o.MeanMotion), true},
+		{"meanElements", "ECCENTRICITY", f(o.Eccentricity), true},
+		{"meanElements", "INCLINATION", f(o.Inclination), true},
+		{"meanElements", "RA_OF_ASC_NODE", f(o.RAOfAscNode), true},
+		{"meanElements", "ARG_OF_PERICENTER", f(o.ArgOfPericenter), true},
+		{"meanElements", "MEAN_ANOMALY", f(o.MeanAnomaly), true},
+
+		{"tleParameters", "EPHEMERIS_TYPE", i(int64(o.EphemerisType)), true},
+		{"tleParameters", "CLASSIFICATION_TYPE", o.ClassificationType, false},
+		{"tleParameters", "NORAD_CAT_ID", i(o.NoradCatID), true},
+		{"tleParameters", "ELEMENT_SET_NO", i(o.ElementSetNo), true},
+		{"tleParameters", "REV_AT_EPOCH", i(o.RevAtEpoch), true},
+		{"tleParameters", "BSTAR", f(o.BStar), true},
+		{"tleParameters", "MEAN_MOTION_DOT", f(o.MeanMotionDot), true},
+		{"tleParameters", "MEAN_MOTION_DDOT", f(o.MeanMotionDDot), true},
+	}
+	for _, p := range ps {
+		if p.numeric && (p.value == "NaN" || strings.HasSuffix(p.value, "Inf")) {
+			return nil, fmt.Errorf("OMM %s is %s", p.key, p.value)
+		}
+	}
+	return ps, nil
+}
+
+// allPairs returns the keyword values of each OMM, so writers can
+// fail before writing anything.
+func allPairs(omms []*OMM) ([][]ommPair, error) {
+	pss := make([][]ommPair, len(omms))
+	for i, o := range omms {
+		ps, err := o.pairs()
+		if err != nil {
+			return nil, err
+		}
+		pss[i] = ps
+	}
+	return pss, nil
+}
+
+// WriteOMMKVN writes the OMM in Keyword = Value Notation (KVN).
+//
+// If the OMM's CreationDate is zero, the current time is used.  It's
+// an error if a number is NaN or infinite.
+func WriteOMMKVN(w io.Writer, o *OMM) error {
+	ps, err := o.pairs()
+	if err != nil {
+		return err
+	}
+	out := bufio.NewWriter(w)
+	fmt.Fprintf(out, "CCSDS_OMM_VERS = 2.0\n")
+	for _, p := range ps {
+		fmt.Fprintf(out, "%s = %s\n", p.key, p.value)
+	}
+	return out.Flush()
+}
+
+// WriteOMMXML writes the OMMs as a CCSDS NDM/XML document.
+//
+// If an OMM's CreationDate is zero, the current time is used.  It's an
+// error if a number is NaN or infinite.
+func WriteOMMXML(w io.Writer, omms []*OMM) error {
+	pss, err := allPairs(omms)
+	if err != nil {
+		return err
+	}
+	out := bufio.NewWriter(w)
+
+	fmt.Fprintf(out, "%s", xml.Header)
+	fmt.Fprintf(out, "<ndm xmlns:xsi=\"http://www.w3.org/2001/XMLSchema-instance\">\n")
+	for _, ps := range pss {
+		var (
+			// section writes the elements in the given
+			// section.
+			section = func(indent, name string) {
+				fmt.Fprintf(out, "%s<%s>\n", indent, name)
+				for _, p := range ps {
+					if p.section != name {
+						continue
+					}
+					fmt.Fprintf(out, "%s  <%s>", indent, p.key)
+					xml.EscapeText(out, []byte(p.value))
+					fmt.Fprintf(out, "</%s>\n", p.key)
+				}
+				fmt.Fprintf(out, "%s</%s>\n", indent, name)
+			}
+		)
+		fmt.Fprintf(out, "  <omm id=\"CCSDS_OMM_VERS\" version=\"2.0\">\n")
+		section("    ", "header")
+		fmt.Fprintf(out, "    <body>\n      <segment>\n")
+		section("        ", "metadata")
+		fmt.Fprintf(out, "        <data>\n")
+		section("          ", "meanElements")
+		section("          ", "tleParameters")
+		fmt.Fprintf(out, "        </data>\n      </segment>\n    </body>\n")
+		fmt.Fprintf(out, "  </omm>\n")
+	}
+	fmt.Fprintf(out, "</ndm>\n")
+	return out.Flush()
+}
+
+// WriteOMMJSON writes the OMMs as a JSON array of objects (like
+// CelesTrak's JSON format).
+//
+// If an OMM's CreationDate is zero, the current time is used.  It's an
+// error if a number is NaN or infinite (which JSON can't represent).
+func WriteOMMJSON(w io.Writer, omms []*OMM) error {
+	pss, err := allPairs(omms)
+	if err != nil {
+		return err
+	}
+	out := bufio.NewWriter(w)
+	fmt.Fprintf(out, "[")
+	for i, ps := range pss {
+		if 0 < i {
+			fmt.Fprintf(out, ",\n")
+		}
+		fmt.Fprintf(out, "{")
+		for j, p := range ps {
+			if 0 < j {
+				fmt.Fprintf(out, ",")
+			}
+			v := p.value
+			if !p.numeric {
+				js, err := json.Marshal(v)
+				if err != nil {
+					return err
+				}
+				v = string(js)
+			}
+			fmt.Fprintf(out, "%q:%s", p.key, v)
+		}
+		fmt.Fprintf(out, "}")
+	}
+	fmt.Fprintf(out, "]\n")
+	return out.Flush()
+}
@@ -0,0 +1,129 @@
+package sgp4go
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Option configures the construction of a TLE.
+//
+// See NewTLE().
+type Option func(*options)
+
+// options collects the settings given by Options.
+type options struct {
+	checksum ChecksumMode
+	gravity  Gravity
+	opsMode  OpsMode
+}
+
+// ErrOption is the underlying error for an Option with an unknown
+// value.
+var ErrOption = errors.New("unknown option value")
+
+// newOptions collects the given Options and checks their values.
+func newOptions(opts []Option) (*options, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	switch o.checksum {
+	case ChecksumWarn, ChecksumStrict, ChecksumIgnore:
+	default:
+		return nil, fmt.Errorf("%w: ChecksumMode %d", ErrOption, o.checksum)
+	}
+	switch o.gravity {
+	case 0, WGS72Old, WGS72, WGS84:
+	default:
+		return nil, fmt.Errorf("%w: Gravity %d", ErrOption, o.gravity)
+	}
+	switch o.opsMode {
+	case 0, AFSPC, Improved:
+	default:
+		return nil, fmt.Errorf("%w: OpsMode %q", ErrOption, byte(o.opsMode))
+	}
+
+	return o, nil
+}
+
+// ChecksumMode determines what NewTLE does with TLE checksums.
+type ChecksumMode int
+
+const (
+	// ChecksumWarn accepts a TLE with a bad checksum but records a
+	// warning.  See TLE.Warnings().  This mode is the default, since
+	// NewTLE has always accepted bad checksums.
+	ChecksumWarn ChecksumMode = iota
+
+	// ChecksumStrict rejects a TLE with a bad checksum.
+	ChecksumStrict
+
+	// ChecksumIgnore does not look at checksums.
+	ChecksumIgnore
+)
+
+// WithChecksum sets the checksum mode.
+func WithChecksum(mode ChecksumMode) Option {
+	return func(o *options) {
+		o.checksum = mode
+	}
+}
+
+// Gravity selects the gravitational constants used by SGP4.
+type Gravity int
+
+const (
+	// WGS72Old is WGS-72 with the low-precision constants from
+	// Spacetrack Report #3.
+	WGS72Old Gravity = 1
+
+	// WGS72 is WGS-72, which is the default (and what element sets
+	// from Space-Track and CelesTrak assume).
+	WGS72 Gravity = 2
+
+	// WGS84 is WGS-84.
+	WGS84 Gravity = 3
+)
+
+// WithGravity sets the gravitational constants.  NewTLE() returns an
+// error for an unknown Gravity.
+func WithGravity(g Gravity) Option {
+	return func(o *options) {
+		o.gravity = g
+	}
+}
+
+// OpsMode is the SGP4 operation mode.
+type OpsMode byte
+
+const (
+	// AFSPC replicates the Air Force Space Command code, which is
+	// the default.
+	AFSPC OpsMode = 'a'
+
+	// Improved uses the improved operation mode from Vallado et al.
+	// The modes differ only in how deep-space (Lyddane) node angles
+	// are normalized.
+	Improved OpsMode = 'i'
+)
+
+// WithOpsMode sets the operation mode.  NewTLE() returns an error for
+// an unknown OpsMode.
+func WithOpsMode(m OpsMode) Option {
+	return func(o *options) {
+		o.opsMode = m
+	}
+}
+
+// apply configures a new TLE.
+func (o *options) apply(tle *TLE) {
+	tle.Rec.whichconst = int64(WGS72)
+	if o.gravity != 0 {
+		tle.Rec.whichconst = int64(o.gravity)
+	}
+	tle.opsmode = byte(AFSPC)
+	if o.opsMode != 0 {
+		tle.opsmode = byte(o.opsMode)
+	}
+}
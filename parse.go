@@ -0,0 +1,420 @@
+package sgp4go
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// lineLength is the number of columns in a TLE line (including the
+// checksum).
+const lineLength = 69
+
+// ParseError reports a malformed field in a TLE.
+//
+// Columns are 1-based and inclusive, which is how TLE formats are
+// usually documented.
+type ParseError struct {
+	// Line is the TLE line (1 or 2) with the problem.
+	Line int
+
+	// Start and End are the first and last columns of the field.
+	Start, End int
+
+	// Field names the field (e.g., "inclination").
+	Field string
+
+	// Text is the content of the field.
+	Text string
+
+	// Err describes the problem.
+	Err error
+}
+
+// Error makes ParseError an error.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("TLE line %d columns %d-%d (%s) %q: %v",
+		e.Line, e.Start, e.End, e.Field, e.Text, e.Err)
+}
+
+// Unwrap returns the underlying error (if any).
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseErrors reports all of the malformed fields in a TLE, with
+// those on line 1 first.
+//
+// errors.As(err, &pe) with a *ParseError pe finds the first one.
+type ParseErrors []*ParseError
+
+// Error makes ParseErrors an error.
+func (es ParseErrors) Error() string {
+	msgs := make([]string, len(es))
+	for i, e := range es {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the errors.
+func (es ParseErrors) Unwrap() []error {
+	errs := make([]error, len(es))
+	for i, e := range es {
+		errs[i] = e
+	}
+	return errs
+}
+
+// Is supports errors.Is for the underlying errors (such as
+// ErrChecksum) with versions of Go before 1.20.
+func (es ParseErrors) Is(target error) bool {
+	for _, e := range es {
+		if errors.Is(e, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As supports errors.As for a *ParseError target, which gets the first
+// error.  (Versions of Go before 1.20 don't use Unwrap() []error.)
+func (es ParseErrors) As(target interface{}) bool {
+	pe, ok := target.(**ParseError)
+	if !ok || len(es) == 0 {
+		return false
+	}
+	*pe = es[0]
+	return true
+}
+
+var (
+	errBlank      = errors.New("blank")
+	errNotDigits  = errors.New("not digits")
+	errNotDecimal = errors.New("not a decimal number")
+	errNotBlank   = errors.New("expected a blank column")
+)
+
+// tleField describes a field in a TLE line.
+type tleField struct {
+	line       int
+	start, end int
+	name       string
+	check      func(s string) error
+}
+
+// text returns the field's text from the given (complete) line.
+func (f tleField) text(line string) string {
+	return line[f.start-1 : f.end]
+}
+
+func (f tleField) err(text string, err error) *ParseError {
+	return &ParseError{
+		Line:  f.line,
+		Start: f.start,
+		End:   f.end,
+		Field: f.name,
+		Text:  text,
+		Err:   err,
+	}
+}
+
+// checkInt checks for an integer, which can be padded with blanks.
+func checkInt(s string) error {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return errBlank
+	}
+	_, err := strconv.ParseInt(s, 10, 64)
+	return err
+}
+
+// checkOptInt is checkInt that also accepts a blank field.
+func checkOptInt(s string) error {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	return checkInt(s)
+}
+
+// checkFloat checks for a decimal number, which can be padded with
+// blanks.  The number is an optional sign and digits with at most one
+// decimal point, so (unlike strconv.ParseFloat) "NaN", "Inf", and
+// exponents are rejected.
+func checkFloat(s string) error {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return errBlank
+	}
+	if s[0] == '+' || s[0] == '-' {
+		s = s[1:]
+	}
+	var digits, points int
+	for _, c := range s {
+		switch {
+		case '0' <= c && c <= '9':
+			digits++
+		case c == '.':
+			points++
+		default:
+			return errNotDecimal
+		}
+	}
+	if digits == 0 || 1 < points {
+		return errNotDecimal
+	}
+	return nil
+}
+
+// checkDigits checks for an unsigned integer with an implied leading
+// decimal point (e.g., eccentricity).
+func checkDigits(s string) error {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return errBlank
+	}
+	for _, c := range s {
+		if c < '0' || '9' < c {
+			return errNotDigits
+		}
+	}
+	return nil
+}
+
+// checkExp checks fields like " 27992-4", which are a sign, a
+// mantissa with an implied leading decimal point, and a signed
+// exponent.
+func checkExp(s string) error {
+	if len(s) != 8 {
+		return fmt.Errorf("bad width %d", len(s))
+	}
+	if !strings.ContainsRune(" +-", rune(s[0])) {
+		return fmt.Errorf("bad sign %q", s[0])
+	}
+	if err := checkDigits(s[1:6]); err != nil {
+		return fmt.Errorf("bad mantissa: %w", err)
+	}
+	if !strings.ContainsRune(" +-", rune(s[6])) {
+		return fmt.Errorf("bad exponent sign %q", s[6])
+	}
+	if s[7] < '0' || '9' < s[7] {
+		return fmt.Errorf("bad exponent %q", s[7])
+	}
+	return nil
+}
+
+// checkBlank checks for a separator column.
+func checkBlank(s string) error {
+	if strings.TrimSpace(s) != "" {
+		return errNotBlank
+	}
+	return nil
+}
+
+// checkEpochDay checks for a day of year with fraction.  See
+// checkEpochYear() for the check against the length of the year.
+func checkEpochDay(s string) error {
+	if err := checkFloat(s); err != nil {
+		return err
+	}
+	d, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return err
+	}
+	if d < 1 || 367 <= d {
+		return fmt.Errorf("day %v out of range", d)
+	}
+	return nil
+}
+
+// checkEpochYear checks that the epoch day of a complete line 1 is in
+// its year, which only has 365 days if it isn't a leap year.
+func checkEpochYear(line string) *ParseError {
+	var (
+		yf, df = epochYearField, epochDayField
+		ys, ds = yf.text(line), df.text(line)
+	)
+	if yf.check(ys) != nil || df.check(ds) != nil {
+		// Already reported.
+		return nil
+	}
+	yr, _ := strconv.ParseInt(strings.TrimSpace(ys), 10, 64)
+	d, _ := strconv.ParseFloat(strings.TrimSpace(ds), 64)
+	if year := fullYear(yr); float64(daysInYear(year)+1) <= d {
+		return df.err(ds, fmt.Errorf("day %v out of range for %d", d, year))
+	}
+	return nil
+}
+
+// checkCatNum checks for a (possibly Alpha-5) catalog number.
+func checkCatNum(s string) error {
+	_, err := ParseAlpha5(s)
+	return err
+}
+
+func checkClassification(s string) error {
+	c := s[0]
+	if c == ' ' || ('A' <= c && c <= 'Z') {
+		return nil
+	}
+	return fmt.Errorf("bad classification %q", c)
+}
+
+func checkEphType(s string) error {
+	c := s[0]
+	if c == ' ' || ('0' <= c && c <= '9') {
+		return nil
+	}
+	return fmt.Errorf("bad ephemeris type %q", c)
+}
+
+// blanks returns fields for separator columns.
+func blanks(line int, cols ...int) []tleField {
+	fs := make([]tleField, len(cols))
+	for i, c := range cols {
+		fs[i] = tleField{line, c, c, "separator", checkBlank}
+	}
+	return fs
+}
+
+// The epoch fields of line 1, which checkEpochYear() checks together.
+var (
+	epochYearField = tleField{1, 19, 20, "epoch year", checkInt}
+	epochDayField  = tleField{1, 21, 32, "epoch day", checkEpochDay}
+)
+
+// line1Fields are the checked fields of line 1.
+//
+//	         1         2         3         4         5         6
+//	123456789012345678901234567890123456789012345678901234567890123456789
+//	1 25544U 98067A   20349.28181795  .00001103  00000-0  27992-4 0  9997
+var line1Fields = append([]tleField{
+	{1, 3, 7, "catalog number", checkCatNum},
+	{1, 8, 8, "classification", checkClassification},
+	epochYearField,
+	epochDayField,
+	{1, 34, 43, "first derivative of mean motion", checkFloat},
+	{1, 45, 52, "second derivative of mean motion", checkExp},
+	{1, 54, 61, "B*", checkExp},
+	{1, 63, 63, "ephemeris type", checkEphType},
+	{1, 65, 68, "element set number", checkOptInt},
+}, blanks(1, 2, 9, 18, 33, 44, 53, 62, 64)...)
+
+// line2Fields are the checked fields of line 2.
+//
+//	         1         2         3         4         5         6
+//	123456789012345678901234567890123456789012345678901234567890123456789
+//	2 25544  51.6443 177.3570 0001731 128.2351  43.6939 15.49184106259930
+var line2Fields = append([]tleField{
+	{2, 3, 7, "catalog number", checkCatNum},
+	{2, 9, 16, "inclination", checkFloat},
+	{2, 18, 25, "right ascension of the ascending node", checkFloat},
+	{2, 27, 33, "eccentricity", checkDigits},
+	{2, 35, 42, "argument of perigee", checkFloat},
+	{2, 44, 51, "mean anomaly", checkFloat},
+	{2, 53, 63, "mean motion", checkFloat},
+	{2, 64, 68, "revolution number", checkOptInt},
+}, blanks(2, 2, 8, 17, 26, 34, 43, 52)...)
+
+// checkLine checks the length, line number, and fields of a line.
+func checkLine(n int, line string, fields []tleField) []*ParseError {
+	if len(line) != lineLength {
+		return []*ParseError{{
+			Line:  n,
+			Start: 1,
+			End:   len(line),
+			Field: "line",
+			Text:  line,
+			Err:   fmt.Errorf("length %d is not %d", len(line), lineLength),
+		}}
+	}
+
+	var errs []*ParseError
+	if line[0] != byte('0'+n) {
+		f := tleField{n, 1, 1, "line number", nil}
+		errs = append(errs, f.err(line[0:1], fmt.Errorf("expected %d", n)))
+	}
+	for _, f := range fields {
+		s := f.text(line)
+		if err := f.check(s); err != nil {
+			errs = append(errs, f.err(s, err))
+		}
+	}
+	return errs
+}
+
+// trimLine removes trailing whitespace (including a carriage
+// return).
+func trimLine(line string) string {
+	return strings.TrimRight(line, " \t\r\n")
+}
+
+// checkLines checks that the given lines are a well-formed TLE.
+//
+// All problems are returned, with those on line 1 first.
+func checkLines(line1, line2 string) []*ParseError {
+	var (
+		errs1 = checkLine(1, line1, line1Fields)
+		errs2 = checkLine(2, line2, line2Fields)
+		errs  = append(errs1, errs2...)
+	)
+
+	if len(line1) == lineLength {
+		if err := checkEpochYear(line1); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(line1) == lineLength && len(line2) == lineLength {
+		var (
+			f      = line2Fields[0]
+			n1, n2 = f.text(line1), f.text(line2)
+			i1, e1 = ParseAlpha5(n1)
+			i2, e2 = ParseAlpha5(n2)
+		)
+		if e1 == nil && e2 == nil && i1 != i2 {
+			errs = append(errs, f.err(n2, fmt.Errorf("does not match %q on line 1", n1)))
+		}
+	}
+
+	return errs
+}
+
+// ErrChecksum is the underlying error of a ParseError for a TLE line
+// with the wrong checksum.
+var ErrChecksum = errors.New("bad checksum")
+
+// Checksum computes the TLE checksum of the given line, which is the
+// sum of the digits in the first 68 columns (with each '-' counting
+// as 1) modulo 10.
+//
+// The line can be shorter than 68 columns, and anything after column
+// 68 is ignored.
+func Checksum(line string) int {
+	if lineLength-1 < len(line) {
+		line = line[0 : lineLength-1]
+	}
+	sum := 0
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case '0' <= c && c <= '9':
+			sum += int(c - '0')
+		case c == '-':
+			sum++
+		}
+	}
+	return sum % 10
+}
+
+// checkChecksum checks the checksum (in column 69) of a complete line.
+func checkChecksum(n int, line string) *ParseError {
+	var (
+		f   = tleField{n, lineLength, lineLength, "checksum", nil}
+		s   = f.text(line)
+		sum = Checksum(line)
+	)
+	if s[0] != byte('0'+sum) {
+		return f.err(s, fmt.Errorf("%w: computed %d", ErrChecksum, sum))
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package sgp4go
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+// verLines returns the line pairs in SGP4-VER.TLE (without the
+// trailing propagation parameters).
+func verLines(t *testing.T) [][2]string {
+	f, err := os.Open("SGP4-VER.TLE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var (
+		pairs [][2]string
+		line1 string
+		s     = bufio.NewScanner(f)
+	)
+	for s.Scan() {
+		line := s.Text()
+		switch {
+		case strings.HasPrefix(line, "1 "):
+			line1 = line
+		case strings.HasPrefix(line, "2 "):
+			pairs = append(pairs, [2]string{line1, line[0:69]})
+		}
+	}
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return pairs
+}
+
+func TestParseVER(t *testing.T) {
+	for _, pair := range verLines(t) {
+		if errs := checkLines(pair[0], pair[1]); 0 < len(errs) {
+			t.Fatalf("%s: %v", pair[0], errs)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	var (
+		lines = strings.Split(ISS, "\n")
+		good1 = lines[1]
+		good2 = lines[2]
+
+		// edit replaces the text at the given (1-based) column.
+		edit = func(line string, col int, s string) string {
+			return line[:col-1] + s + line[col-1+len(s):]
+		}
+	)
+
+	for _, tc := range []struct {
+		name         string
+		line1, line2 string
+		line         int
+		field        string
+	}{
+		{"empty", "", good2, 1, "line"},
+		{"short", good1, good2[0:60], 2, "line"},
+		{"swapped", good2, good1, 1, "line number"},
+		{"catnum", edit(good1, 3, "2554x"), good2, 1, "catalog number"},
+		{"mismatch", good1, edit(good2, 3, "25545"), 2, "catalog number"},
+		{"epoch", edit(good1, 21, "x"), good2, 1, "epoch day"},
+		{"doy", edit(good1, 21, "400"), good2, 1, "epoch day"},
+		{"leapday", edit(good1, 19, "21366.50000000"), good2, 1, "epoch day"},
+		{"nanday", edit(good1, 21, "     NaN    "), good2, 1, "epoch day"},
+		{"ndot", edit(good1, 36, "x"), good2, 1, "first derivative of mean motion"},
+		{"nddot", edit(good1, 51, "x"), good2, 1, "second derivative of mean motion"},
+		{"bstar", edit(good1, 59, "?"), good2, 1, "B*"},
+		{"shifted", edit(good1, 33, "5"), good2, 1, "separator"},
+		{"inclination", good1, edit(good2, 10, "1.6.4"), 2, "inclination"},
+		{"eccentricity", good1, edit(good2, 28, "-"), 2, "eccentricity"},
+		{"meanmotion", good1, edit(good2, 53, "x"), 2, "mean motion"},
+		{"nan", good1, edit(good2, 9, "     NaN"), 2, "inclination"},
+		{"inf", good1, edit(good2, 53, "        Inf"), 2, "mean motion"},
+		{"signedinf", good1, edit(good2, 53, "       +Inf"), 2, "mean motion"},
+		{"exponent", good1, edit(good2, 44, " 4.37e+1"), 2, "mean anomaly"},
+		{"hex", edit(good1, 34, "    0x1p-4"), good2, 1, "first derivative of mean motion"},
+		{"revnum", good1, edit(good2, 64, "2x"), 2, "revolution number"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewTLE(tc.line1, tc.line2)
+			if err == nil {
+				t.Fatal("no error")
+			}
+			var pe *ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("%T: %v", err, err)
+			}
+			if pe.Line != tc.line || pe.Field != tc.field {
+				t.Fatal(pe)
+			}
+		})
+	}
+
+	t.Run("several", func(t *testing.T) {
+		_, err := NewTLE(good1, edit(edit(good2, 10, "1.6.4"), 53, "x"))
+		var pes ParseErrors
+		if !errors.As(err, &pes) || len(pes) != 2 {
+			t.Fatalf("%T: %v", err, err)
+		}
+		if pes[0].Field != "inclination" || pes[1].Field != "mean motion" {
+			t.Fatal(pes)
+		}
+		if !strings.Contains(err.Error(), "inclination") || !strings.Contains(err.Error(), "mean motion") {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("leapyear", func(t *testing.T) {
+		if _, err := NewTLE(edit(good1, 19, "20366.50000000"), good2); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("crlf", func(t *testing.T) {
+		if _, err := NewTLE(good1+" \r\n", good2+"\r"); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestChecksum(t *testing.T) {
+	for _, line := range strings.Split(ISS, "\n")[1:] {
+		if got, want := Checksum(line), int(line[68]-'0'); got != want {
+			t.Fatal(line, got, want)
+		}
+	}
+
+	var (
+		lines = strings.Split(ISS, "\n")
+		// Corrupt a digit in the mean motion.
+		line2 = lines[2][:55] + "9" + lines[2][56:]
+	)
+
+	t.Run("strict", func(t *testing.T) {
+		_, err := NewTLE(lines[1], line2, WithChecksum(ChecksumStrict))
+		if !errors.Is(err, ErrChecksum) {
+			t.Fatal(err)
+		}
+		var pes ParseErrors
+		if !errors.As(err, &pes) || len(pes) != 1 || pes[0].Line != 2 || pes[0].Start != 69 {
+			t.Fatal(err)
+		}
+
+		// With other malformed fields, the checksum is reported
+		// along with them.
+		_, err = NewTLE(lines[1], line2[:8]+"     NaN"+line2[16:], WithChecksum(ChecksumStrict))
+		if !errors.As(err, &pes) || len(pes) != 2 || pes[0].Field != "inclination" || pes[1].Field != "checksum" {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("warn", func(t *testing.T) {
+		for _, opts := range [][]Option{nil, {WithChecksum(ChecksumWarn)}} {
+			o, err := NewTLE(lines[1], line2, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ws := o.Warnings(); len(ws) != 1 || !errors.Is(ws[0], ErrChecksum) {
+				t.Fatal(ws)
+			}
+		}
+	})
+
+	t.Run("ignore", func(t *testing.T) {
+		o, err := NewTLE(lines[1], line2, WithChecksum(ChecksumIgnore))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ws := o.Warnings(); len(ws) != 0 {
+			t.Fatal(ws)
+		}
+	})
+}
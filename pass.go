@@ -0,0 +1,189 @@
+package sgp4go
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// AzEl is a direction in degrees.
+type AzEl struct {
+	Az, El float64
+}
+
+// Mask is an elevation mask.  The zero value is the horizon.
+type Mask struct {
+	// Min is the minimum elevation in degrees.
+	Min float64
+
+	// Terrain (if not empty) gives the minimum elevation at some
+	// azimuths, which must be in increasing order.  Between them,
+	// the elevation is interpolated linearly (wrapping around at 360
+	// degrees).
+	Terrain []AzEl
+}
+
+// At returns the minimum elevation at the given azimuth, which is the
+// greater of Min and the terrain.
+func (m *Mask) At(az float64) float64 {
+	n := len(m.Terrain)
+	if n == 0 {
+		return m.Min
+	}
+
+	az = math.Mod(az, 360)
+	if az < 0 {
+		az += 360
+	}
+	// The first point at or after az.
+	i := sort.Search(n, func(i int) bool {
+		return az <= m.Terrain[i].Az
+	})
+	var (
+		a = m.Terrain[(i+n-1)%n]
+		b = m.Terrain[i%n]
+		w = b.Az - a.Az
+		d = az - a.Az
+	)
+	if w <= 0 {
+		w += 360
+	}
+	if d < 0 {
+		d += 360
+	}
+	el := a.El
+	if 0 < w && w < 360 {
+		el += (b.El - a.El) * d / w
+	}
+	return math.Max(m.Min, el)
+}
+
+// PassEvent is an Observer's view of a satellite at a time.
+type PassEvent struct {
+	Time time.Time
+	Look Look
+}
+
+// Pass is an interval when a satellite is above an Observer's Mask.
+type Pass struct {
+	// AOS is acquisition of signal, when the satellite rises above
+	// the mask.
+	AOS PassEvent
+
+	// TCA is the time of the pass's greatest elevation.  Since the
+	// elevation changes so little near its peak, this time is only
+	// good to tens of milliseconds.  With Terrain in the Mask, the
+	// greatest elevation can be at the AOS or LOS.
+	TCA PassEvent
+
+	// LOS is loss of signal, when the satellite sets below the
+	// mask.
+	LOS PassEvent
+}
+
+// PassFinder finds passes of satellites over an Observer.
+//
+// The satellite is propagated in coarse steps, and the rising and
+// setting times are then found by bisection.  The greatest elevation
+// is found by golden-section search.  A brief pass that rises and sets
+// between steps is found by searching near steps with locally greatest
+// elevation, but a step much longer than the shortest passes of
+// interest can still miss some.
+type PassFinder struct {
+	Observer *Observer
+
+	// Mask is the elevation mask, which applies to the Observer's
+	// (possibly refracted) elevation.
+	Mask Mask
+
+	// Step is the coarse step, which defaults to a minute.
+	Step time.Duration
+
+	// Tolerance is the accuracy of the times of events, which
+	// defaults to a millisecond.
+	Tolerance time.Duration
+}
+
+func (f *PassFinder) step() time.Duration {
+	if f.Step <= 0 {
+		return time.Minute
+	}
+	return f.Step
+}
+
+func (f *PassFinder) tolerance() time.Duration {
+	if f.Tolerance <= 0 {
+		return time.Millisecond
+	}
+	return f.Tolerance
+}
+
+// passSample is a view with its height above the mask.
+type passSample struct {
+	PassEvent
+	above float64
+}
+
+// sample returns the view of the TLE at the given time.
+func (f *PassFinder) sample(tle *TLE, t time.Time) (passSample, error) {
+	l, err := f.Observer.LookAt(tle, t)
+	if err != nil {
+		return passSample{}, err
+	}
+	return passSample{PassEvent{t, l}, l.El - f.Mask.At(l.Az)}, nil
+}
+
+// Passes returns the passes of the TLE from start to stop.
+//
+// A pass that is in progress at start (or stop) has its AOS (or LOS)
+// at that time.  A propagation error (see HasDecayed()) stops the
+// search, and Passes returns the passes found before it along with the
+// error.
+func (f *PassFinder) Passes(tle *TLE, start, stop time.Time) ([]Pass, error) {
+	// below is negative when the satellite is above the mask.
+	below := func(t time.Time) (float64, error) {
+		s, err := f.sample(tle, t)
+		return -s.above, err
+	}
+
+	var passes []Pass
+	ivals, err := searchFunc(below).negative(start, stop, f.step(), f.tolerance())
+	for _, iv := range ivals {
+		p, err := f.pass(tle, iv)
+		if err != nil {
+			return passes, err
+		}
+		passes = append(passes, p)
+	}
+	return passes, err
+}
+
+// pass returns the pass with the given AOS and LOS, finding its TCA.
+func (f *PassFinder) pass(tle *TLE, iv Interval) (Pass, error) {
+	var p Pass
+	aos, err := f.sample(tle, iv.Start)
+	if err != nil {
+		return p, err
+	}
+	los, err := f.sample(tle, iv.Stop)
+	if err != nil {
+		return p, err
+	}
+
+	// depth is negative elevation, so its minimum is the TCA.
+	depth := func(t time.Time) (float64, error) {
+		s, err := f.sample(tle, t)
+		return -s.Look.El, err
+	}
+	low, err := searchFunc(depth).minimum(iv.Start, iv.Stop, f.step(), f.tolerance())
+	if err != nil {
+		return p, err
+	}
+	tca, err := f.sample(tle, low.t)
+	if err != nil {
+		return p, err
+	}
+
+	p.AOS, p.TCA, p.LOS = aos.PassEvent, tca.PassEvent, los.PassEvent
+	return p, nil
+}
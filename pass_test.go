@@ -0,0 +1,198 @@
+package sgp4go
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestMask(t *testing.T) {
+	m := Mask{
+		Min:     5,
+		Terrain: []AzEl{{10, 20}, {90, 0}, {270, 10}},
+	}
+	for _, tc := range []struct {
+		az, el float64
+	}{
+		{10, 20},
+		{50, 10},
+		{90, 5},
+		{180, 5},
+		{270, 10},
+		{320, 15},
+		{0, 19},
+		{-40, 15},
+		{370, 20},
+	} {
+		if el := m.At(tc.az); 1e-9 < math.Abs(el-tc.el) {
+			t.Fatal(tc.az, el)
+		}
+	}
+
+	m = Mask{Terrain: []AzEl{{123, 7}}}
+	if el := m.At(45); el != 7 {
+		t.Fatal(el)
+	}
+	if el := (&Mask{}).At(45); el != 0 {
+		t.Fatal(el)
+	}
+}
+
+// brutePasses finds the passes by sampling every second.
+func brutePasses(t *testing.T, f *PassFinder, tle *TLE, start, stop time.Time) []Pass {
+	var (
+		passes []Pass
+		in     bool
+	)
+	for at := start; at.Before(stop); at = at.Add(time.Second) {
+		s, err := f.sample(tle, at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		switch {
+		case !in && 0 <= s.above:
+			passes = append(passes, Pass{AOS: s.PassEvent, TCA: s.PassEvent})
+			in = true
+		case in && s.above < 0:
+			passes[len(passes)-1].LOS = s.PassEvent
+			in = false
+		case in:
+			if p := &passes[len(passes)-1]; p.TCA.Look.El < s.Look.El {
+				p.TCA = s.PassEvent
+			}
+		}
+	}
+	return passes
+}
+
+func TestPasses(t *testing.T) {
+	var (
+		tle   = getExample(t)
+		start = time.Date(2020, 12, 14, 7, 0, 0, 0, time.UTC)
+		stop  = start.Add(24 * time.Hour)
+		o     = &Observer{Position: LatLonAlt{Lat: 38.9, Lon: -77.0, Alt: 0.1}}
+	)
+
+	for _, mask := range []Mask{
+		{},
+		{Min: 10},
+		{Terrain: []AzEl{{0, 2}, {90, 25}, {180, 2}, {270, 15}}},
+	} {
+		var (
+			f        = &PassFinder{Observer: o, Mask: mask}
+			want     = brutePasses(t, f, tle, start, stop)
+			got, err = f.Passes(tle, start, stop)
+			tol      = f.tolerance()
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) == 0 || len(got) != len(want) {
+			t.Fatal(mask, len(got), len(want))
+		}
+		for i, p := range got {
+			w := want[i]
+			// With terrain, the greatest elevation can be at the
+			// AOS or LOS.
+			if p.TCA.Time.Before(p.AOS.Time) || p.LOS.Time.Before(p.TCA.Time) || !p.AOS.Time.Before(p.LOS.Time) {
+				t.Fatal(i, p)
+			}
+			// The brute-force times are the first seconds in and
+			// out of the pass.
+			if d := w.AOS.Time.Sub(p.AOS.Time); d < 0 || time.Second+tol < d {
+				t.Fatal(i, p.AOS, w.AOS)
+			}
+			if d := w.LOS.Time.Sub(p.LOS.Time); d < 0 || time.Second+tol < d {
+				t.Fatal(i, p.LOS, w.LOS)
+			}
+			if p.TCA.Look.El < w.TCA.Look.El {
+				t.Fatal(i, p.TCA, w.TCA)
+			}
+			if d := p.TCA.Time.Sub(w.TCA.Time); d < -time.Second || time.Second < d {
+				t.Fatal(i, p.TCA, w.TCA)
+			}
+			for _, e := range []PassEvent{p.AOS, p.LOS} {
+				if d := e.Look.El - mask.At(e.Look.Az); d < 0 || 0.01 < d {
+					t.Fatal(i, e, d)
+				}
+			}
+		}
+	}
+}
+
+func TestPassesShort(t *testing.T) {
+	var (
+		tle   = getExample(t)
+		start = time.Date(2020, 12, 14, 7, 0, 0, 0, time.UTC)
+		stop  = start.Add(24 * time.Hour)
+		o     = &Observer{Position: LatLonAlt{Lat: 38.9, Lon: -77.0, Alt: 0.1}}
+		fine  = &PassFinder{Observer: o, Mask: Mask{Min: 10}, Step: time.Minute}
+	)
+
+	want, err := fine.Passes(tle, start, stop)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A coarse step still finds the passes that rise and set between
+	// steps.
+	coarse := &PassFinder{Observer: o, Mask: fine.Mask, Step: 5 * time.Minute}
+	got, err := coarse.Passes(tle, start, stop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) {
+		t.Fatal(len(got), len(want))
+	}
+	for i := range got {
+		for _, d := range []time.Duration{
+			got[i].AOS.Time.Sub(want[i].AOS.Time),
+			got[i].LOS.Time.Sub(want[i].LOS.Time),
+		} {
+			if d < -10*time.Millisecond || 10*time.Millisecond < d {
+				t.Fatal(i, got[i], want[i])
+			}
+		}
+		// The elevation is so flat at its peak that the TCA is
+		// less precise.
+		if d := got[i].TCA.Time.Sub(want[i].TCA.Time); d < -100*time.Millisecond || 100*time.Millisecond < d {
+			t.Fatal(i, got[i], want[i])
+		}
+	}
+
+	// A window that starts and ends during a pass.
+	p := want[0]
+	mid := p.AOS.Time.Add(p.LOS.Time.Sub(p.AOS.Time) / 4)
+	end := p.LOS.Time.Add(-p.LOS.Time.Sub(p.AOS.Time) / 4)
+	got, err = fine.Passes(tle, mid, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || !got[0].AOS.Time.Equal(mid) || !got[0].LOS.Time.Equal(end) {
+		t.Fatal(got)
+	}
+}
+
+func TestPassesDecay(t *testing.T) {
+	var (
+		tle   = getDecaying(t)
+		f     = &PassFinder{Observer: &Observer{}}
+		start = time.Date(2006, 6, 19, 6, 0, 0, 0, time.UTC)
+	)
+	if _, err := f.Passes(tle, start, start.Add(24*time.Hour)); !HasDecayed(err) {
+		t.Fatal(err)
+	}
+}
+
+func BenchmarkPasses(b *testing.B) {
+	var (
+		tle   = getExample(b)
+		start = time.Date(2020, 12, 14, 7, 0, 0, 0, time.UTC)
+		f     = &PassFinder{Observer: &Observer{Position: LatLonAlt{Lat: 38.9, Lon: -77.0}}}
+	)
+	for i := 0; i < b.N; i++ {
+		if _, err := f.Passes(tle, start, start.Add(24*time.Hour)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package sgp4go
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ReadError reports a problem with a record read by a Reader.
+type ReadError struct {
+	// Line is the (1-based) input line number of the problem.
+	Line int
+
+	// Err describes the problem.
+	Err error
+}
+
+// Error makes ReadError an error.
+func (e *ReadError) Error() string {
+	return fmt.Sprintf("TLE input line %d: %v", e.Line, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ReadError) Unwrap() error {
+	return e.Err
+}
+
+// Reader reads TLEs, which can each be preceded by a name line, from
+// text.
+//
+// Each record is either two lines (a "2LE") or a name line followed
+// by two lines (a "3LE"), and the two kinds of records can be mixed.
+// A name line can have a "0 " prefix, which is removed.  Blank lines
+// and comment lines (which start with '#') are ignored, as is
+// trailing whitespace (including carriage returns).
+type Reader struct {
+	s    *bufio.Scanner
+	opts []Option
+
+	// n is the number of input lines read.
+	n int
+
+	// pushed is a line that was read but not used.
+	pushed    string
+	pushedN   int
+	hasPushed bool
+}
+
+// NewReader makes a Reader that constructs TLEs with the given
+// options.
+func NewReader(r io.Reader, opts ...Option) *Reader {
+	return &Reader{
+		s:    bufio.NewScanner(r),
+		opts: opts,
+	}
+}
+
+// line returns the next line that isn't blank or a comment along with
+// its line number.
+//
+// At the end of the input, the error is io.EOF.
+func (r *Reader) line() (string, int, error) {
+	if r.hasPushed {
+		r.hasPushed = false
+		return r.pushed, r.pushedN, nil
+	}
+	for r.s.Scan() {
+		r.n++
+		line := trimLine(r.s.Text())
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		return line, r.n, nil
+	}
+	if err := r.s.Err(); err != nil {
+		return "", r.n, err
+	}
+	return "", r.n, io.EOF
+}
+
+// push returns a line so that the next call to line() returns it.
+func (r *Reader) push(line string, n int) {
+	r.pushed, r.pushedN, r.hasPushed = line, n, true
+}
+
+// isLine reports whether the given line looks like TLE line 1 or 2
+// (as given).
+func isLine(line string, n int) bool {
+	return 2 <= len(line) && line[0] == byte('0'+n) && line[1] == ' '
+}
+
+// Next returns the next record's name (which is empty for a 2LE) and
+// TLE.
+//
+// At the end of the input, the error is io.EOF.  Other errors are
+// *ReadErrors, and Next can be called again to continue with the
+// following record.
+func (r *Reader) Next() (string, *TLE, error) {
+	line, n, err := r.line()
+	if err != nil {
+		return "", nil, err
+	}
+
+	var name string
+	if !isLine(line, 1) {
+		name = strings.TrimSpace(strings.TrimPrefix(line, "0 "))
+		if line, n, err = r.line(); err == io.EOF {
+			return "", nil, &ReadError{n, fmt.Errorf("missing line 1 after name %q", name)}
+		} else if err != nil {
+			return "", nil, err
+		}
+		if !isLine(line, 1) {
+			r.push(line, n)
+			return "", nil, &ReadError{n, fmt.Errorf("expected line 1 after name %q", name)}
+		}
+	}
+
+	line1, n1 := line, n
+	line2, n2, err := r.line()
+	if err == io.EOF {
+		return "", nil, &ReadError{n1 + 1, fmt.Errorf("missing line 2")}
+	} else if err != nil {
+		return "", nil, err
+	}
+	if !isLine(line2, 2) {
+		r.push(line2, n2)
+		return "", nil, &ReadError{n2, fmt.Errorf("expected line 2")}
+	}
+
+	tle, err := NewTLE(line1, line2, r.opts...)
+	if err != nil {
+		n := n1
+		var pe *ParseError
+		if errors.As(err, &pe) && pe.Line == 2 {
+			n = n2
+		}
+		return "", nil, &ReadError{n, err}
+	}
+
+	return name, tle, nil
+}
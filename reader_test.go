@@ -0,0 +1,76 @@
+package sgp4go
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestReader(t *testing.T) {
+	var (
+		lines = strings.Split(ISS, "\n")
+		bad2  = lines[2][:10] + "x" + lines[2][11:]
+		in    = strings.Join([]string{
+			"# A comment",
+			"",
+			lines[0],
+			lines[1] + "\r",
+			lines[2] + "  ",
+			lines[1],
+			lines[2],
+			"",
+			"0 ISS (ZARYA)",
+			lines[1],
+			lines[2],
+			"NAME WITHOUT LINES",
+			"ISS",
+			lines[1],
+			bad2,
+			lines[1],
+		}, "\n")
+		r = NewReader(strings.NewReader(in))
+	)
+
+	type result struct {
+		name string
+		line int
+	}
+
+	var got []result
+	for {
+		name, tle, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		var re *ReadError
+		if err != nil && !errors.As(err, &re) {
+			t.Fatal(err)
+		}
+		if err != nil {
+			got = append(got, result{"", re.Line})
+			continue
+		}
+		if tle.NoradCatNum() != 25544 {
+			t.Fatal(tle.NoradCatNum())
+		}
+		got = append(got, result{name, 0})
+	}
+
+	want := []result{
+		{"ISS (ZARYA)", 0},
+		{"", 0},
+		{"ISS (ZARYA)", 0},
+		{"", 13},
+		{"", 15},
+		{"", 17},
+	}
+	if len(got) != len(want) {
+		t.Fatal(got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatal(i, got)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package sgp4go
+
+import (
+	"math"
+	"time"
+)
+
+// The finders (PassFinder, EclipseFinder, and VisualPassFinder) search
+// for events with the functions here.  A search function is a
+// function of time (such as an angle from a boundary) that can fail
+// when propagation does.
+
+// searchFunc is a function of time to search.
+type searchFunc func(time.Time) (float64, error)
+
+// Interval is a span of time.
+type Interval struct {
+	Start, Stop time.Time
+}
+
+// timeSample is the value of a function at a time.
+type timeSample struct {
+	t time.Time
+	v float64
+}
+
+func (f searchFunc) sample(t time.Time) (timeSample, error) {
+	v, err := f(t)
+	return timeSample{t, v}, err
+}
+
+// crossing returns the time when f changes sign between a and b (to
+// the given tolerance) by bisection.  The time is on the side where f
+// is negative.
+func (f searchFunc) crossing(a, b timeSample, tol time.Duration) (time.Time, error) {
+	for tol < b.t.Sub(a.t) {
+		m, err := f.sample(a.t.Add(b.t.Sub(a.t) / 2))
+		if err != nil {
+			return time.Time{}, err
+		}
+		if (m.v < 0) == (a.v < 0) {
+			a = m
+		} else {
+			b = m
+		}
+	}
+	if a.v < 0 {
+		return a.t, nil
+	}
+	return b.t, nil
+}
+
+// least returns the least value of f between a and b (to the given
+// tolerance) by golden-section search, which assumes that f has one
+// minimum there.
+func (f searchFunc) least(a, b time.Time, tol time.Duration) (timeSample, error) {
+	var (
+		r      = (math.Sqrt(5) - 1) / 2
+		at     = func(x float64) (timeSample, error) { return f.sample(a.Add(time.Duration(x))) }
+		lo, hi = 0.0, float64(b.Sub(a))
+		x1     = hi - r*(hi-lo)
+		x2     = lo + r*(hi-lo)
+	)
+	s1, err := at(x1)
+	if err != nil {
+		return timeSample{}, err
+	}
+	s2, err := at(x2)
+	if err != nil {
+		return timeSample{}, err
+	}
+	for float64(tol) < hi-lo {
+		if s1.v < s2.v {
+			hi, x2, s2 = x2, x1, s1
+			x1 = hi - r*(hi-lo)
+			if s1, err = at(x1); err != nil {
+				return timeSample{}, err
+			}
+		} else {
+			lo, x1, s1 = x1, x2, s2
+			x2 = lo + r*(hi-lo)
+			if s2, err = at(x2); err != nil {
+				return timeSample{}, err
+			}
+		}
+	}
+	if s1.v < s2.v {
+		return s1, nil
+	}
+	return s2, nil
+}
+
+// minimum returns the least value of f from start to stop.
+//
+// It samples f at the given step, and then it searches around the
+// least sample (with least()) to the given tolerance.
+func (f searchFunc) minimum(start, stop time.Time, step, tol time.Duration) (timeSample, error) {
+	var (
+		best  timeSample
+		first = true
+	)
+	for t := start; ; t = t.Add(step) {
+		if stop.Before(t) {
+			t = stop
+		}
+		s, err := f.sample(t)
+		if err != nil {
+			return timeSample{}, err
+		}
+		if first || s.v < best.v {
+			best, first = s, false
+		}
+		if !t.Before(stop) {
+			break
+		}
+	}
+
+	var (
+		a = best.t.Add(-step)
+		b = best.t.Add(step)
+	)
+	if a.Before(start) {
+		a = start
+	}
+	if stop.Before(b) {
+		b = stop
+	}
+	s, err := f.least(a, b, tol)
+	if err != nil {
+		return timeSample{}, err
+	}
+	if best.v < s.v {
+		return best, nil
+	}
+	return s, nil
+}
+
+// negative returns the intervals from start to stop when f is
+// negative.
+//
+// It samples f at the given step, and it finds the boundaries by
+// bisection to the given tolerance.  Near samples that are locally
+// least (but not negative), it looks for brief intervals by
+// golden-section search.  An interval that is in progress at start
+// (or stop) starts (or stops) at that time.
+//
+// If f returns an error, negative returns the intervals found so far
+// along with the error.
+func (f searchFunc) negative(start, stop time.Time, step, tol time.Duration) ([]Interval, error) {
+	var (
+		ivals      []Interval
+		begin      time.Time
+		in         bool
+		s0, s1, s2 timeSample
+		n          int
+	)
+
+	for t := start; ; t = t.Add(step) {
+		if stop.Before(t) {
+			t = stop
+		}
+		s, err := f.sample(t)
+		if err != nil {
+			return ivals, err
+		}
+		s0, s1, s2 = s1, s2, s
+		n++
+
+		switch {
+		case n == 1:
+			if s.v < 0 {
+				in, begin = true, t
+			}
+		case !in && s.v < 0:
+			if begin, err = f.crossing(s1, s, tol); err != nil {
+				return ivals, err
+			}
+			in = true
+		case in && 0 <= s.v:
+			end, err := f.crossing(s1, s, tol)
+			if err != nil {
+				return ivals, err
+			}
+			ivals = append(ivals, Interval{begin, end})
+			in = false
+		case !in && 3 <= n && s1.v < s0.v && s1.v < s.v:
+			// Perhaps a brief interval between s0 and s2.
+			low, err := f.least(s0.t, s.t, tol)
+			if err != nil {
+				return ivals, err
+			}
+			if 0 <= low.v {
+				break
+			}
+			b, err := f.crossing(s0, low, tol)
+			if err != nil {
+				return ivals, err
+			}
+			e, err := f.crossing(low, s, tol)
+			if err != nil {
+				return ivals, err
+			}
+			ivals = append(ivals, Interval{b, e})
+		}
+
+		if !t.Before(stop) {
+			break
+		}
+	}
+
+	if in {
+		ivals = append(ivals, Interval{begin, s2.t})
+	}
+
+	return ivals, nil
+}
+
+// innerStep returns a step for searching within an interval, which is
+// the given step unless that's too long to sample the interval a few
+// times.
+func innerStep(iv Interval, step, tol time.Duration) time.Duration {
+	if d := iv.Stop.Sub(iv.Start) / 4; d < step && tol < d {
+		return d
+	}
+	return step
+}
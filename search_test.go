@@ -0,0 +1,74 @@
+package sgp4go
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestNegative(t *testing.T) {
+	var (
+		start = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		stop  = start.Add(time.Hour)
+		dip   = start.Add(1234567 * time.Millisecond)
+		f     = func(t time.Time) (float64, error) {
+			// Negative for two seconds around dip and at the ends.
+			var (
+				s = t.Sub(dip).Seconds()
+				e = math.Min(t.Sub(start).Seconds(), stop.Sub(t).Seconds())
+			)
+			return math.Min(s*s-1, e-90), nil
+		}
+	)
+
+	ivals, err := searchFunc(f).negative(start, stop, time.Minute, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Interval{
+		{start, start.Add(90 * time.Second)},
+		{dip.Add(-time.Second), dip.Add(time.Second)},
+		{stop.Add(-90 * time.Second), stop},
+	}
+	if len(ivals) != len(want) {
+		t.Fatal(ivals)
+	}
+	for i, iv := range ivals {
+		for _, d := range []time.Duration{iv.Start.Sub(want[i].Start), iv.Stop.Sub(want[i].Stop)} {
+			if d < -time.Millisecond || time.Millisecond < d {
+				t.Fatal(i, iv, want[i])
+			}
+		}
+	}
+}
+
+func TestMinimum(t *testing.T) {
+	var (
+		start = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		stop  = start.Add(time.Hour)
+		low   = start.Add(1234567 * time.Millisecond)
+		f     = func(t time.Time) (float64, error) {
+			s := t.Sub(low).Seconds()
+			return s*s - 1, nil
+		}
+	)
+
+	for _, step := range []time.Duration{time.Minute, 2 * time.Hour} {
+		s, err := searchFunc(f).minimum(start, stop, step, time.Millisecond)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d := s.t.Sub(low); d < -time.Millisecond || time.Millisecond < d {
+			t.Fatal(step, s.t, low)
+		}
+	}
+
+	// At the ends of the range.
+	s, err := searchFunc(f).minimum(start, low.Add(-time.Hour/4), time.Minute, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.t.Equal(low.Add(-time.Hour / 4)) {
+		t.Fatal(s.t)
+	}
+}
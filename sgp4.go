@@ -90,6 +90,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 	"unsafe"
 	// "github.com/elliotchance/c2go/noarch"
 )
@@ -315,6 +316,14 @@ type elsetRec struct {
 	rteosq         float64
 	sinio          float64
 }
+
+// TLE is an initialized two-line element set, which can be propagated.
+//
+// Propagation (Prop(), PropInto(), etc.) doesn't modify the TLE, so
+// any number of goroutines can propagate the same TLE concurrently.
+// Set() does modify the TLE, and callers that use it concurrently
+// with propagation must synchronize (perhaps using the embedded
+// Mutex, which propagation no longer uses).
 type TLE struct {
 	sync.Mutex
 
@@ -323,7 +332,6 @@ type TLE struct {
 	line2     [70]byte
 	intlid    [12]byte
 	objectNum int64
-	epoch     int64
 	ndot      float64
 	nddot     float64
 	bstar     float64
@@ -336,6 +344,9 @@ type TLE struct {
 	n         float64
 	revnum    int64
 	sgp4Error int64
+	warnings  []error
+	opsmode   byte
+	initError int64
 }
 
 // parseLines - transpiled function from  /home/somebody/aholinch/sgp4/src/c/all.c:16
@@ -349,7 +360,9 @@ type TLE struct {
 // intlid
 //
 func parseLines(tle *TLE, line1 *byte, line2 *byte) {
-	(*tle).Rec.whichconst = int64(2)
+	if (*tle).Rec.whichconst == 0 {
+		(*tle).Rec.whichconst = int64(2)
+	}
 	strncpy64(&(*tle).line1[0], line1, int64(uint64(int64(69))))
 	strncpy64(&(*tle).line2[0], line2, int64(uint64(int64(69))))
 	*((*byte)(func() unsafe.Pointer {
@@ -362,7 +375,7 @@ func parseLines(tle *TLE, line1 *byte, line2 *byte) {
 	}())) = byte(int64(0))
 	strncpy64(&(*tle).intlid[0], &*((*byte)(unsafe.Pointer(uintptr(unsafe.Pointer(line1)) + (uintptr)(int64(9))*unsafe.Sizeof(*line1)))), int64(uint64(int64(8))))
 	(*tle).Rec.classification = *((*byte)(unsafe.Pointer(uintptr(unsafe.Pointer(line1)) + (uintptr)(int64(7))*unsafe.Sizeof(*line1))))
-	(*tle).objectNum = int64(gd(line1, int64(2), int64(7)))
+	(*tle).objectNum, _ = ParseAlpha5(gs(line1, int64(2), int64(7)))
 	(*tle).ndot = gdi(line1, int64(35), int64(44))
 	if int64(*((*byte)(unsafe.Pointer(uintptr(unsafe.Pointer(line1)) + (uintptr)(int64(33))*unsafe.Sizeof(*line1))))) == int64('-') {
 		(*tle).ndot *= -1
@@ -378,6 +391,7 @@ func parseLines(tle *TLE, line1 *byte, line2 *byte) {
 	}
 	(*tle).bstar *= math.Pow(10, gd(line1, int64(59), int64(61)))
 	(*tle).elnum = int64(gd(line1, int64(64), int64(68)))
+	(*tle).Rec.ephtype = int64(gd(line1, int64(62), int64(63)))
 	(*tle).incDeg = gd(line2, int64(8), int64(16))
 	(*tle).raanDeg = gd(line2, int64(17), int64(25))
 	(*tle).ecc = gdi(line2, int64(26), int64(33))
@@ -386,7 +400,7 @@ func parseLines(tle *TLE, line1 *byte, line2 *byte) {
 	(*tle).n = gd(line2, int64(52), int64(63))
 	(*tle).revnum = int64(gd(line2, int64(63), int64(68)))
 	(*tle).sgp4Error = int64(0)
-	(*tle).epoch = parseEpoch(&(*tle).Rec, &*((*byte)(unsafe.Pointer(uintptr(unsafe.Pointer(line1)) + (uintptr)(int64(18))*unsafe.Sizeof(*line1)))))
+	parseEpoch(&(*tle).Rec, &*((*byte)(unsafe.Pointer(uintptr(unsafe.Pointer(line1)) + (uintptr)(int64(18))*unsafe.Sizeof(*line1)))))
 	setValsToRec(tle, &(*tle).Rec)
 }
 
@@ -408,7 +422,7 @@ func isLeap(year int64) bool_ {
 // parseEpoch - transpiled function from  /home/somebody/aholinch/sgp4/src/c/all.c:88
 // convert doy to mon, day
 //
-func parseEpoch(rec *elsetRec, str *byte) int64 {
+func parseEpoch(rec *elsetRec, str *byte) {
 	var tmp []byte = make([]byte, 16, 16)
 	strncpy64(&tmp[0], str, int64(uint64(int64(14))))
 	*((*byte)(func() unsafe.Pointer {
@@ -499,20 +513,34 @@ func parseEpoch(rec *elsetRec, str *byte) int64 {
 	day = doy
 	jday(year, mon, day, hr, mn, sec, &(*rec).jdsatepoch, &(*rec).jdsatepochF)
 
-	var diff float64 = (*rec).jdsatepoch - 2.4405875e+06
-	var diff2 float64 = 8.64e+07 * (*rec).jdsatepochF
-	diff *= 8.64e+07
-	var epoch = int64(diff2)
-	epoch += int64(diff)
+	// The epoch is kept at full precision in jdsatepoch and
+	// jdsatepochF (rather than in Unix milliseconds).
+}
 
-	return epoch
+// propagate runs SGP4 on a copy of the TLE's initialized record
+// (which sgp4() uses for scratch space) and returns the error code.
+// If the TLE could not be initialized, the code is the
+// initialization error.
+//
+// Since the TLE isn't modified, concurrent calls are safe.
+func propagate(tle *TLE, mins float64, r *float64, v *float64) int64 {
+	if (*tle).initError != 0 {
+		return (*tle).initError
+	}
+	scratch := (*tle).Rec
+	scratch.error = 0
+	sgp4(&scratch, mins, r, v)
+	return scratch.error
 }
 
-// getRVForDate - transpiled function from  /home/somebody/aholinch/sgp4/src/c/all.c:164
-func getRVForDate(tle *TLE, millisSince1970 int64, r *float64, v *float64) {
-	var diff float64 = float64(millisSince1970) - float64((*tle).epoch)
-	diff /= 60000
-	getRV(tle, diff, r, v)
+// minutesSinceEpoch returns the minutes from the record's epoch to
+// the given time.
+//
+// The whole days and the day fractions are differenced separately to
+// preserve nanosecond resolution.
+func minutesSinceEpoch(rec *elsetRec, t time.Time) float64 {
+	jd := toJulianDate(t)
+	return ((jd.Day - (*rec).jdsatepoch) + (jd.Frac - (*rec).jdsatepochF)) * 1440
 }
 
 // getRV - transpiled function from  /home/somebody/aholinch/sgp4/src/c/all.c:171
@@ -540,6 +568,13 @@ func gd(str *byte, ind1 int64, ind2 int64) float64 {
 	return num
 }
 
+// gs returns the (NUL-terminated) string in the given range.
+func gs(str *byte, ind1 int64, ind2 int64) string {
+	var tmp []byte = make([]byte, ind2-ind1+1)
+	strncpy64(&tmp[0], &*((*byte)(unsafe.Pointer(uintptr(unsafe.Pointer(str)) + (uintptr)(ind1)*unsafe.Sizeof(*str)))), ind2-ind1)
+	return cs2s(tmp)
+}
+
 // gdi - transpiled function from  /home/somebody/aholinch/sgp4/src/c/all.c:190
 // parse with an implied decimal place
 //
@@ -563,7 +598,7 @@ func gdi(str *byte, ind1 int64, ind2 int64) float64 {
 
 	// num = noarch.Strtod(&tmp[0], nil)
 	// noarch
-	num, _ = strconv.ParseFloat(cs2s(tmp), 64)
+	num, _ = strconv.ParseFloat(strings.TrimSpace(cs2s(tmp)), 64)
 
 	return num
 }
@@ -571,7 +606,7 @@ func gdi(str *byte, ind1 int64, ind2 int64) float64 {
 // setValsToRec - transpiled function from  /home/somebody/aholinch/sgp4/src/c/all.c:203
 // 229.1831180523293
 //
-func setValsToRec(tle *TLE, rec *elsetRec) {
+func setValsToRec(tle *TLE, rec *elsetRec) int64 {
 	var xpdotp float64 = 1440.0 / (2.0 * 3.141592653589793)
 	(*rec).elnum = (*tle).elnum
 	(*rec).revnum = (*tle).revnum
@@ -585,7 +620,14 @@ func setValsToRec(tle *TLE, rec *elsetRec) {
 	(*rec).no_kozai = (*tle).n / xpdotp
 	(*rec).ndot = (*tle).ndot / (xpdotp * 1440.0)
 	(*rec).nddot = (*tle).nddot / (xpdotp * 1440.0 * 1440.0)
-	sgp4init('a', rec)
+	opsmode := (*tle).opsmode
+	if opsmode == 0 {
+		opsmode = 'a'
+	}
+	sgp4init(opsmode, rec)
+	// sgp4init propagates to the epoch, which sets rec.error.
+	(*tle).initError = (*rec).error
+	return (*rec).error
 }
 
 // dpper - transpiled function from  /home/somebody/aholinch/sgp4/src/c/all.c:348
@@ -2352,13 +2394,13 @@ func sgp4(satrec *elsetRec, tsince float64, r *float64, v *float64) bool_ {
 	(*satrec).argpm = math.Mod((*satrec).argpm, (2 * 3.141592653589793))
 	xlm = math.Mod(xlm, (2 * 3.141592653589793))
 	(*satrec).mm = math.Mod(xlm-(*satrec).argpm-(*satrec).nodem, (2 * 3.141592653589793))
-	(*satrec).am = (*satrec).am
-	(*satrec).em = (*satrec).em
+	// (*satrec).am = (*satrec).am
+	// (*satrec).em = (*satrec).em
 	(*satrec).im = (*satrec).inclm
 	(*satrec).Om = (*satrec).nodem
 	(*satrec).om = (*satrec).argpm
-	(*satrec).mm = (*satrec).mm
-	(*satrec).nm = (*satrec).nm
+	// (*satrec).mm = (*satrec).mm
+	// (*satrec).nm = (*satrec).nm
 	(*satrec).sinim = math.Sin((*satrec).inclm)
 	(*satrec).cosim = math.Cos((*satrec).inclm)
 	(*satrec).ep = (*satrec).em
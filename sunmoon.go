@@ -0,0 +1,118 @@
+package sgp4go
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// AU is the astronomical unit in km.
+	AU = 149597870.7
+
+	// SunRadius is the Sun's radius in km.
+	SunRadius = 696000.0
+
+	// MoonRadius is the Moon's radius in km.
+	MoonRadius = 1737.4
+)
+
+// sinDeg and cosDeg take degrees.
+func sinDeg(x float64) float64 {
+	return math.Sin(x * math.Pi / 180)
+}
+
+func cosDeg(x float64) float64 {
+	return math.Cos(x * math.Pi / 180)
+}
+
+// modToTEME converts a Mean of Date position at the given time to
+// TEME.  It's the inverse of TEMEToMOD() with only the leap seconds.
+func modToTEME(t time.Time, r Vect, leaps *LeapSeconds) Vect {
+	var (
+		dpsi, deps, meaneps = nutation(centuries(t, leaps), EOP{})
+		eqe                 = equinoxMatrix(dpsi, meaneps)
+		nut                 = nutationMatrix(dpsi, deps, meaneps)
+	)
+	return eqe.tmul(nut.tmul(r))
+}
+
+// SunPosition returns the Sun's position (km) in TEME at the given
+// time using Vallado's low-precision algorithm (from the Astronomical
+// Almanac).
+//
+// From 1950 to 2050, the direction is good to about 0.01 degree, and
+// the distance is good to about 0.0001 AU.  Terrestrial Time comes
+// from DefaultLeapSeconds, but Observer.SunElevation() and
+// Observer.Magnitude() use the leap seconds of the Observer's EOP.
+func SunPosition(t time.Time) Vect {
+	return sunPosition(t, nil)
+}
+
+// sunPosition is SunPosition() with the given leap seconds.
+func sunPosition(t time.Time, leaps *LeapSeconds) Vect {
+	var (
+		ttt = centuries(t, leaps)
+
+		// The mean longitude and mean anomaly (degrees).
+		lon  = 280.460 + 36000.771*ttt
+		anom = 357.5291092 + 35999.05034*ttt
+
+		// The ecliptic longitude and obliquity (degrees).
+		ecl = lon + 1.914666471*sinDeg(anom) + 0.019994643*sinDeg(2*anom)
+		eps = 23.439291 - 0.0130042*ttt
+
+		r = (1.000140612 - 0.016708617*cosDeg(anom) - 0.000139589*cosDeg(2*anom)) * AU
+	)
+	return modToTEME(t, Vect{
+		r * cosDeg(ecl),
+		r * cosDeg(eps) * sinDeg(ecl),
+		r * sinDeg(eps) * sinDeg(ecl),
+	}, leaps)
+}
+
+// MoonPosition returns the Moon's position (km) in TEME at the given
+// time using Vallado's low-precision algorithm (from the Astronomical
+// Almanac).
+//
+// The ecliptic longitude is good to about 0.3 degree, the ecliptic
+// latitude to about 0.2 degree, and the distance to about 1300 km.
+// Terrestrial Time comes from DefaultLeapSeconds.
+func MoonPosition(t time.Time) Vect {
+	return moonPosition(t, nil)
+}
+
+// moonPosition is MoonPosition() with the given leap seconds.
+func moonPosition(t time.Time, leaps *LeapSeconds) Vect {
+	var (
+		ttt = centuries(t, leaps)
+
+		// The ecliptic longitude and latitude (degrees).
+		lon = 218.32 + 481267.8813*ttt +
+			6.29*sinDeg(134.9+477198.85*ttt) -
+			1.27*sinDeg(259.2-413335.38*ttt) +
+			0.66*sinDeg(235.7+890534.23*ttt) +
+			0.21*sinDeg(269.9+954397.70*ttt) -
+			0.19*sinDeg(357.5+35999.05*ttt) -
+			0.11*sinDeg(186.6+966404.05*ttt)
+		lat = 5.13*sinDeg(93.3+483202.03*ttt) +
+			0.28*sinDeg(228.2+960400.87*ttt) -
+			0.28*sinDeg(318.3+6003.18*ttt) -
+			0.17*sinDeg(217.6-407332.20*ttt)
+
+		// The horizontal parallax (degrees).
+		parallax = 0.9508 +
+			0.0518*cosDeg(134.9+477198.85*ttt) +
+			0.0095*cosDeg(259.2-413335.38*ttt) +
+			0.0078*cosDeg(235.7+890534.23*ttt) +
+			0.0028*cosDeg(269.9+954397.70*ttt)
+
+		eps = ((5.04e-7*ttt-1.64e-7)*ttt-0.0130042)*ttt + 23.439291
+
+		r = WGS84Ellipsoid.A / sinDeg(parallax)
+	)
+	return modToTEME(t, Vect{
+		r * cosDeg(lat) * cosDeg(lon),
+		r * (cosDeg(eps)*cosDeg(lat)*sinDeg(lon) - sinDeg(eps)*sinDeg(lat)),
+		r * (sinDeg(eps)*cosDeg(lat)*sinDeg(lon) + cosDeg(eps)*sinDeg(lat)),
+	}, leaps)
+}
@@ -0,0 +1,66 @@
+package sgp4go
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// separation returns the angle between the vectors in degrees.
+func separation(a, b Vect) float64 {
+	return math.Atan2(norm(cross(a, b)), dot(a, b)) * 180 / math.Pi
+}
+
+func TestSunPosition(t *testing.T) {
+	// Vallado's Example 5-1 (MOD), which uses UTC rather than TT.
+	at := time.Date(2006, 4, 2, 0, 0, 0, 0, time.UTC)
+	r, _ := TEMEToMOD(at, SunPosition(at), Vect{}, EOP{})
+	near(t, "Example 5-1", r, Vect{0.9771945 * AU, 0.1924424 * AU, 0.0834308 * AU}, 3e-5*AU)
+
+	for _, tc := range []struct {
+		what string
+		t    time.Time
+		dec  float64
+		r    float64
+	}{
+		{"equinox", time.Date(2020, 3, 20, 3, 50, 0, 0, time.UTC), 0, 0.99597},
+		{"solstice", time.Date(2020, 6, 20, 21, 44, 0, 0, time.UTC), 23.4367, 1.01629},
+		{"perihelion", time.Date(2020, 1, 5, 7, 48, 0, 0, time.UTC), -22.66, 0.98324},
+	} {
+		r, _ := TEMEToTOD(tc.t, SunPosition(tc.t), Vect{}, EOP{})
+		dec := math.Asin(r.Z/norm(r)) * 180 / math.Pi
+		if 0.01 < math.Abs(dec-tc.dec) || 1e-4 < math.Abs(norm(r)/AU-tc.r) {
+			t.Fatal(tc.what, dec, norm(r)/AU)
+		}
+	}
+}
+
+func TestMoonPosition(t *testing.T) {
+	// Vallado's Example 5-3 (MOD), which uses UTC rather than TT, so
+	// the Moon has moved about 60 km.
+	at := time.Date(1994, 4, 28, 0, 0, 0, 0, time.UTC)
+	r, _ := TEMEToMOD(at, MoonPosition(at), Vect{}, EOP{})
+	near(t, "Example 5-3", r, Vect{-134240.626, -311571.590, -126693.785}, 100)
+
+	// At the full moon, the Moon is opposite the Sun (except for its
+	// ecliptic latitude, which was about 1.5 degrees).
+	full := time.Date(2020, 12, 30, 3, 28, 0, 0, time.UTC)
+	if a := separation(MoonPosition(full), SunPosition(full)); a < 178 {
+		t.Fatal(a)
+	}
+
+	// At the new moon of the total solar eclipse, they're aligned to
+	// within the Moon's apparent radius.
+	eclipse := time.Date(2020, 12, 14, 16, 14, 0, 0, time.UTC)
+	if a := separation(MoonPosition(eclipse), SunPosition(eclipse)); 0.5 < a {
+		t.Fatal(a)
+	}
+
+	// The Moon stays between perigee and apogee.
+	for d := 0; d < 60; d++ {
+		at := full.Add(time.Duration(d) * 24 * time.Hour)
+		if r := norm(MoonPosition(at)); r < 355000 || 407000 < r {
+			t.Fatal(at, r)
+		}
+	}
+}
@@ -2,33 +2,58 @@ package sgp4go
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"math"
-	"strings"
 	"time"
 )
 
 // Error adds error behavior to SGP4 status codes.
+//
+// Propagation errors are *PropErrors, which wrap an Error, so
+// errors.Is(err, ErrDecayed) and the like work.
 type Error int
 
+// SGP4 status codes.
+const (
+	// ErrEccentricity means the mean eccentricity is out of range
+	// (or the semi-major axis is less than 0.95 Earth radii).
+	ErrEccentricity Error = 1
+
+	// ErrMeanMotion means the mean motion is negative.
+	ErrMeanMotion Error = 2
+
+	// ErrPerturbedEccentricity means the perturbed eccentricity is
+	// out of range.
+	ErrPerturbedEccentricity Error = 3
+
+	// ErrSemiLatusRectum means the semi-latus rectum is negative.
+	ErrSemiLatusRectum Error = 4
+
+	// ErrSubOrbital means the epoch elements are sub-orbital.
+	ErrSubOrbital Error = 5
+
+	// ErrDecayed means the satellite has decayed.
+	ErrDecayed Error = 6
+)
+
 // Error makes Error an error.
 //
 // Also see HasDecayed().
 func (e Error) Error() string {
 	var msg string
-	switch int(e) {
-	case 1:
+	switch e {
+	case ErrEccentricity:
 		msg = "mean elements, ecc >= 1.0 or ecc < -0.001 or a < 0.95 er"
-	case 2:
+	case ErrMeanMotion:
 		msg = "mean motion less than 0.0"
-	case 3:
+	case ErrPerturbedEccentricity:
 		msg = "pert elements, ecc < 0.0  or  ecc > 1.0"
-	case 4:
+	case ErrSemiLatusRectum:
 		msg = "semi-latus rectum < 0.0"
-	case 5:
+	case ErrSubOrbital:
 		msg = "epoch elements are sub-orbital"
-	case 6:
-		// See HasDecayed().
+	case ErrDecayed:
 		msg = decayError
 	default:
 		msg = "NA"
@@ -36,40 +61,101 @@ func (e Error) Error() string {
 	return fmt.Sprintf("code=%d: %s", e, msg)
 }
 
-// decayError is a magic string used to detected that situation.
+// decayError is the message for ErrDecayed.
 const decayError = "satellite has decayed"
 
 // HasDecayed determines if the given error indicates the object has
 // decayed.
+//
+// HasDecayed(err) is errors.Is(err, ErrDecayed).
 func HasDecayed(e error) bool {
-	// Too much trouble to try to check type (in the face of
-	// fmt.wrapError, etc).
-	if e == nil {
-		return false
+	return errors.Is(e, ErrDecayed)
+}
+
+// PropError reports an SGP4 propagation failure.
+//
+// Propagating a TLE that couldn't be initialized (see Set()) fails
+// with the initialization status code.
+type PropError struct {
+	// Code is the SGP4 status code.
+	Code Error
+
+	// CatNum is the TLE's catalog number.
+	CatNum int64
+
+	// Time is the requested time.
+	Time time.Time
+
+	// Minutes is the requested time in minutes since the TLE's
+	// epoch.
+	Minutes float64
+}
+
+// Error makes PropError an error.
+func (e *PropError) Error() string {
+	return fmt.Sprintf("SGP4 error for %d at %s (%f minutes after epoch): %v",
+		e.CatNum, e.Time.Format(time.RFC3339Nano), e.Minutes, e.Code)
+}
+
+// Unwrap returns the SGP4 status code.
+func (e *PropError) Unwrap() error {
+	return e.Code
+}
+
+// InitError reports that SGP4 could not be initialized with a TLE's
+// elements.
+type InitError struct {
+	// Code is the SGP4 status code.
+	Code Error
+
+	// CatNum is the TLE's catalog number.
+	CatNum int64
+}
+
+// Error makes InitError an error.
+func (e *InitError) Error() string {
+	return fmt.Sprintf("SGP4 initialization error for %d: %v", e.CatNum, e.Code)
+}
+
+// Unwrap returns the SGP4 status code.
+func (e *InitError) Unwrap() error {
+	return e.Code
+}
+
+// initErr returns an *InitError if the TLE could not be initialized.
+func (tle *TLE) initErr() error {
+	if tle.initError == 0 {
+		return nil
+	}
+	return &InitError{
+		Code:   Error(tle.initError),
+		CatNum: tle.objectNum,
+	}
+}
+
+// propError makes a *PropError for the given status code.
+func (tle *TLE) propError(code int64, t time.Time, mins float64) error {
+	return &PropError{
+		Code:    Error(code),
+		CatNum:  tle.objectNum,
+		Time:    t,
+		Minutes: mins,
 	}
-	return strings.Contains(e.Error(), decayError)
 }
 
 // PropUnixMillis attempts to propagate a the given time in Unix
 // milliseconds.
 //
-// Also see Prop().
+// Also see Prop(), which has nanosecond resolution.
 func (tle *TLE) PropUnixMillis(ms int64) ([]float64, []float64, error) {
-	// ToDo: Support higher time resolution.
 	var (
-		r = make([]float64, 3)
-		v = make([]float64, 3)
+		r    = make([]float64, 3)
+		v    = make([]float64, 3)
+		mins = minutesSinceEpoch(&tle.Rec, time.Unix(0, ms*1000_000))
 	)
 
-	tle.Lock()
-	tle.Rec.error = 0
-	getRVForDate(tle, ms, (*float64)(&r[0]), (*float64)(&v[0]))
-	e := tle.sgp4Error
-	tle.sgp4Error = 0
-	tle.Unlock()
-
-	if e != 0 {
-		return nil, nil, fmt.Errorf("SGP4 error at ms=%d: %w", ms, Error(e))
+	if e := propagate(tle, mins, &r[0], &v[0]); e != 0 {
+		return nil, nil, tle.propError(e, time.Unix(0, ms*1000_000).UTC(), mins)
 	}
 	return r, v, nil
 }
@@ -83,38 +169,80 @@ func (tle *TLE) PropForMins(mins float64) ([]float64, []float64, error) {
 		v = make([]float64, 3)
 	)
 
-	tle.Lock()
-	tle.Rec.error = 0
-	getRV(tle, mins, (*float64)(&r[0]), (*float64)(&v[0]))
-	e := tle.sgp4Error
-	tle.Rec.error = 0
-	tle.Unlock()
-
-	if e != 0 {
-		return nil, nil, fmt.Errorf("SGP4 error at mins=%f: %w", mins, Error(e))
+	if e := propagate(tle, mins, &r[0], &v[0]); e != 0 {
+		t := epochTime(tle).Add(time.Duration(math.Round(mins * float64(time.Minute))))
+		return nil, nil, tle.propError(e, t, mins)
 	}
 	return r, v, nil
 }
 
 // NewTLE constructs a new TLE (which can be propagated).
 //
+// Trailing whitespace is ignored.  If the lines are malformed, the
+// error is a ParseErrors with every malformed field.  If SGP4 can't
+// be initialized with the elements, the error is an *InitError.
+// Checksums are verified according to WithChecksum() (with warnings
+// by default), and with ChecksumStrict a bad checksum is in the
+// ParseErrors.  Also see WithGravity() and WithOpsMode().
+//
 // Also see Set().
-func NewTLE(line1, line2 string) (*TLE, error) {
+func NewTLE(line1, line2 string, opts ...Option) (*TLE, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	line1, line2 = trimLine(line1), trimLine(line2)
+	var (
+		errs     = checkLines(line1, line2)
+		warnings []error
+	)
+	for i, line := range []string{line1, line2} {
+		if o.checksum == ChecksumIgnore || len(line) != lineLength {
+			continue
+		}
+		err := checkChecksum(i+1, line)
+		switch {
+		case err == nil:
+		case o.checksum == ChecksumStrict:
+			errs = append(errs, err)
+		default:
+			warnings = append(warnings, err)
+		}
+	}
+	if 0 < len(errs) {
+		return nil, ParseErrors(errs)
+	}
+
 	tle := &TLE{}
+	o.apply(tle)
 	bs1 := []byte(line1)
 	bs2 := []byte(line2)
 	parseLines(tle, (*byte)(&bs1[0]), (*byte)(&bs2[0]))
-	// ToDo: Detect and report errors!
+	if err := tle.initErr(); err != nil {
+		return nil, err
+	}
+	tle.warnings = warnings
 	return tle, nil
 }
 
+// Warnings returns the problems that were tolerated when the TLE was
+// constructed (e.g., bad checksums with ChecksumWarn).
+func (tle *TLE) Warnings() []error {
+	return tle.warnings
+}
+
 // Set allows the caller to provide high-precision values than what a
 // TLE can perhaps provide; however, this code has not (yet) been
 // tested with respect to this additional precision.
-func (tle *TLE) Set(epoch time.Time, mm1, mm2, bstar, incl, ra, ecc, aop, anom, mm, rev float64) {
+//
+// If SGP4 can't be initialized with the new values, the error is an
+// *InitError, and the TLE can't be propagated (until a successful
+// Set()).
+func (tle *TLE) Set(epoch time.Time, mm1, mm2, bstar, incl, ra, ecc, aop, anom, mm, rev float64) error {
 
 	if !epoch.IsZero() {
-		tle.epoch = epoch.UnixNano() / 1000_000
+		setEpoch(tle, epoch)
 	}
 	if mm1 != 0 {
 		tle.ndot = mm1
@@ -157,6 +285,7 @@ func (tle *TLE) Set(epoch time.Time, mm1, mm2, bstar, incl, ra, ecc, aop, anom,
 	}
 
 	setValsToRec(tle, &(*tle).Rec)
+	return tle.initErr()
 }
 
 // Vect is a 3-vector.
@@ -169,27 +298,39 @@ type Ephemeris struct {
 	// V is velocity in km/sec.
 	V Vect
 
-	// ECI is position in Earth-Centered Inertial coordinates.
+	// ECI is position in km in Earth-Centered Inertial coordinates,
+	// which for SGP4 is the True Equator, Mean Equinox (TEME) frame.
+	// See TEMEToECEF().
 	ECI Vect
 }
 
 // Prop propagates the given TLE.
 //
-// Currently the resolution is only milliseconds.
+// The resolution is the resolution of time.Time (nanoseconds).
 func (o *TLE) Prop(t time.Time) (Ephemeris, error) {
-	// ToDo: Increase resolution.
-	p, v, err := o.PropUnixMillis(t.UnixNano() / 1000 / 1000)
 	var e Ephemeris
-	if err == nil {
-		e = Ephemeris{
-			ECI: Vect{float64(p[0]), float64(p[1]), float64(p[2])},
-			V:   Vect{float64(v[0]), float64(v[1]), float64(v[2])},
-		}
+	if err := o.PropInto(t, &e); err != nil {
+		return Ephemeris{}, err
 	}
-	return e, err
+	return e, nil
+}
+
+// PropInto is Prop() that writes the result to the given Ephemeris.
+//
+// Unless there's an error, PropInto does not allocate.  If there's an
+// error, the Ephemeris is zeroed.
+func (o *TLE) PropInto(t time.Time, e *Ephemeris) error {
+	mins := minutesSinceEpoch(&o.Rec, t)
+	if code := propagate(o, mins, &e.ECI.X, &e.V.X); code != 0 {
+		*e = Ephemeris{}
+		return o.propError(code, t, mins)
+	}
+	return nil
 }
 
 // NoradCatNum returns the NORAD catalog number of the TLE.
+//
+// Alpha-5 catalog numbers are decoded (see ParseAlpha5()).
 func (o *TLE) NoradCatNum() int {
 	return int(o.objectNum)
 }
@@ -206,6 +347,8 @@ func (tle *TLE) SemiMajorAxisMeters() float64 {
 }
 
 // Lines returns line1 and line2 used to generate the TLE.
+//
+// These lines do not reflect changes made by Set().  See Format().
 func (tle *TLE) Lines() (string, string) {
 	f := func(bs [70]byte) string {
 		return string(bytes.Trim(bs[0:], "\x00"))
@@ -214,6 +357,8 @@ func (tle *TLE) Lines() (string, string) {
 }
 
 // ObjectNum returns the object number as parsed from lines.
+//
+// Alpha-5 catalog numbers are decoded (see ParseAlpha5()).
 func (tle *TLE) ObjectNum() int64 {
 	return tle.objectNum
 }
@@ -221,7 +366,7 @@ func (tle *TLE) ObjectNum() int64 {
 // EqualElements checks that elements and related data are equal.
 func (x *TLE) EqualValues(y *TLE) bool {
 
-	if x.epoch != y.epoch {
+	if x.Rec.jdsatepoch != y.Rec.jdsatepoch || x.Rec.jdsatepochF != y.Rec.jdsatepochF {
 		return false
 	}
 	if x.ndot != y.ndot {
@@ -1,8 +1,11 @@
 package sgp4go
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -54,10 +57,10 @@ func Example() {
 	fmt.Printf("%#v", e)
 
 	// Output:
-	// sgp4go.Ephemeris{V:sgp4go.Vect{X:-5.677866567405847, Y:-3.554868601056742, Z:3.696844342787944}, ECI:sgp4go.Vect{X:-4522.507182662008, Y:2857.518282965092, Z:-4201.949004177315}}
+	// sgp4go.Ephemeris{V:sgp4go.Vect{X:-5.677871600288684, Y:-3.554865421056027, Z:3.6968396532391288}, ECI:sgp4go.Vect{X:-4522.502186150667, Y:2857.5214112385906, Z:-4201.952257396956}}
 }
 
-func getExample(t *testing.T) *TLE {
+func getExample(t testing.TB) *TLE {
 	var (
 		tle = `ISS (ZARYA)             
 1 25544U 98067A   20349.28181795  .00001103  00000-0  27992-4 0  9997
@@ -74,6 +77,18 @@ func getExample(t *testing.T) *TLE {
 	return o
 }
 
+// getDecaying returns a TLE (from SGP4-VER.TLE) for an object that
+// decays within 440 minutes of its epoch.
+func getDecaying(t testing.TB) *TLE {
+	o, err := NewTLE(
+		"1 29141U 85108AA  06170.26783845  .99999999  00000-0  13519-0 0   718",
+		"2 29141  82.4288 273.4882 0015848 277.2124  83.9133 15.93343074  6828")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return o
+}
+
 func TestSemiMajorAxis(t *testing.T) {
 	var (
 		alt = 408.0
@@ -124,9 +139,347 @@ func TestEqual(t *testing.T) {
 	})
 	
 	t.Run("different", func(t *testing.T) {
-		y.epoch++
+		y.Rec.jdsatepochF += 1e-9
 		if x.EqualValues(y) {
 			t.Fatal(true)
 		}
 	})
 }
+
+func TestPropResolution(t *testing.T) {
+	var (
+		o     = getExample(t)
+		epoch = o.Epoch()
+	)
+
+	if mins := minutesSinceEpoch(&o.Rec, epoch); 1e-9 < math.Abs(mins) {
+		t.Fatal(mins)
+	}
+
+	r0, _, err := o.PropForMins(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := o.Prop(epoch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := math.Abs(e.ECI.X - r0[0]); 1e-6 < d {
+		t.Fatal(d)
+	}
+
+	// A microsecond should move the ISS several millimeters.
+	e1, err := o.Prop(epoch.Add(time.Microsecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := math.Abs(e1.ECI.X - e.ECI.X); d < 1e-6 || 1e-5 < d {
+		t.Fatal(d)
+	}
+}
+
+func TestGravityOpsMode(t *testing.T) {
+	var (
+		lines = strings.Split(ISS, "\n")
+		mins  = 1440.0
+	)
+
+	prop := func(opts ...Option) (*TLE, []float64) {
+		o, err := NewTLE(lines[1], lines[2], opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, _, err := o.PropForMins(mins)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return o, r
+	}
+
+	dist := func(a, b []float64) float64 {
+		return math.Sqrt((a[0]-b[0])*(a[0]-b[0]) + (a[1]-b[1])*(a[1]-b[1]) + (a[2]-b[2])*(a[2]-b[2]))
+	}
+
+	o, r := prop()
+	if o.Rec.whichconst != int64(WGS72) || o.Rec.operationmode != byte(AFSPC) {
+		t.Fatal(o.Rec.whichconst, o.Rec.operationmode)
+	}
+
+	_, r72 := prop(WithGravity(WGS72), WithOpsMode(AFSPC))
+	if d := dist(r, r72); d != 0 {
+		t.Fatal(d)
+	}
+
+	// Different constants should move the ISS, though (after a day)
+	// by less than 100 km.
+	for _, g := range []Gravity{WGS72Old, WGS84} {
+		o, rg := prop(WithGravity(g))
+		if o.Rec.whichconst != int64(g) {
+			t.Fatal(o.Rec.whichconst)
+		}
+		if d := dist(r, rg); d == 0 || 100 < d {
+			t.Fatal(g, d)
+		}
+	}
+
+	if o, _ := prop(WithOpsMode(Improved)); o.Rec.operationmode != byte(Improved) {
+		t.Fatal(o.Rec.operationmode)
+	}
+
+	// The modes differ for a deep-space orbit with a low inclination
+	// (in the Lyddane node normalization).
+	var (
+		line1 = "1 23599U 95029B   06171.76535463  .00085586  12891-6  12956-2 0  2905"
+		line2 = "2 23599   6.9327   0.2849 5782022 274.4436  25.2425  4.47796565123555"
+		modes = map[OpsMode][]float64{}
+	)
+	for _, m := range []OpsMode{AFSPC, Improved} {
+		o, err := NewTLE(line1, line2, WithOpsMode(m))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if modes[m], _, err = o.PropForMins(mins); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if d := dist(modes[AFSPC], modes[Improved]); d < 0.1 || 10 < d {
+		t.Fatal(d)
+	}
+
+	omm, err := ParseOMMKVN(strings.NewReader(issKVN))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, opt := range []Option{WithGravity(4), WithGravity(-1), WithOpsMode('x'), WithChecksum(7)} {
+		if _, err := NewTLE(lines[1], lines[2], opt); !errors.Is(err, ErrOption) {
+			t.Fatal(err)
+		}
+		if _, err := NewTLEFromOMM(omm, opt); !errors.Is(err, ErrOption) {
+			t.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPropInto(b *testing.B) {
+	var (
+		o = getExample(b)
+		t = o.Epoch().Add(time.Hour)
+		e Ephemeris
+	)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := o.PropInto(t, &e); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPropIntoParallel(b *testing.B) {
+	var (
+		o = getExample(b)
+		t = o.Epoch().Add(time.Hour)
+	)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		var e Ephemeris
+		for pb.Next() {
+			if err := o.PropInto(t, &e); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestPropIntoAllocs(t *testing.T) {
+	var (
+		o  = getExample(t)
+		t0 = o.Epoch().Add(time.Hour)
+		e  Ephemeris
+	)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := o.PropInto(t0, &e); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Fatal(allocs)
+	}
+}
+
+func TestPropConcurrent(t *testing.T) {
+	// A 12-hour resonant Molniya exercises the deep-space
+	// integrator, which sgp4() restarts as needed.
+	var (
+		line1 = "1 08195U 75081A   06176.33215444  .00000099  00000-0  11873-3 0   813"
+		line2 = "2 08195  64.1586 279.0717 6877146 264.7651  20.2257  2.00491383225656"
+	)
+
+	o, err := NewTLE(line1, line2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Serial results, computed by the original function with a
+	// single record, in order.
+	ref, err := NewTLE(line1, line2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		n    = 50
+		want = make([]Ephemeris, n)
+	)
+	for i := range want {
+		getRV(ref, float64(i)*120, &want[i].ECI.X, &want[i].V.X)
+		if ref.sgp4Error != 0 {
+			t.Fatal(ref.sgp4Error)
+		}
+	}
+
+	var (
+		wg   sync.WaitGroup
+		errs = make(chan error, 8)
+	)
+	for g := 0; g < cap(errs); g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			// Go backwards (and skip around) to avoid
+			// depending on the integrator's state.
+			for k := 0; k < n; k++ {
+				i := (n - 1 - k + g*7) % n
+				r, v, err := o.PropForMins(float64(i) * 120)
+				if err != nil {
+					errs <- err
+					return
+				}
+				got := Ephemeris{V: Vect{v[0], v[1], v[2]}, ECI: Vect{r[0], r[1], r[2]}}
+				if d := math.Abs(got.ECI.X - want[i].ECI.X); 1e-8 < d {
+					errs <- fmt.Errorf("%d: %v %v", i, got, want[i])
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+}
+
+func TestPropError(t *testing.T) {
+	var (
+		o    = getDecaying(t)
+		mins = 440.0
+		at   = o.Epoch().Add(time.Duration(mins) * time.Minute)
+	)
+
+	check := func(t *testing.T, err error) {
+		var pe *PropError
+		if !errors.As(err, &pe) {
+			t.Fatalf("%T: %v", err, err)
+		}
+		// PropUnixMillis truncates to milliseconds.
+		if d := pe.Time.Sub(at); d <= -time.Millisecond || time.Millisecond <= d {
+			t.Fatal(pe)
+		}
+		if pe.Code != ErrDecayed || pe.CatNum != 29141 || 1e-4 < math.Abs(pe.Minutes-mins) {
+			t.Fatal(pe)
+		}
+		if !errors.Is(err, ErrDecayed) || errors.Is(err, ErrEccentricity) || !HasDecayed(err) {
+			t.Fatal(err)
+		}
+		var code Error
+		if !errors.As(err, &code) || code != ErrDecayed {
+			t.Fatal(code)
+		}
+	}
+
+	t.Run("Prop", func(t *testing.T) {
+		_, err := o.Prop(at)
+		check(t, err)
+	})
+
+	t.Run("PropInto", func(t *testing.T) {
+		e := Ephemeris{V: Vect{1, 2, 3}, ECI: Vect{4, 5, 6}}
+		check(t, o.PropInto(at, &e))
+		if e != (Ephemeris{}) {
+			t.Fatal(e)
+		}
+	})
+
+	t.Run("PropForMins", func(t *testing.T) {
+		_, _, err := o.PropForMins(mins)
+		check(t, err)
+	})
+
+	t.Run("PropUnixMillis", func(t *testing.T) {
+		_, _, err := o.PropUnixMillis(at.UnixNano() / 1e6)
+		check(t, err)
+	})
+
+	t.Run("PropTimes", func(t *testing.T) {
+		_, err := o.PropTimes([]time.Time{at}, NewEphemerides(1))
+		check(t, err)
+	})
+
+	if HasDecayed(nil) || HasDecayed(ErrSubOrbital) {
+		t.Fatal(true)
+	}
+}
+
+func TestInitError(t *testing.T) {
+	// This element set (from SGP4-VER.TLE) has a mean motion of
+	// 0.00001 revs/day.
+	var (
+		line1 = "1 33334U 78066F   06174.85818871  .00000620  00000-0  10000-3 0  6809"
+		line2 = "2 33334  68.4714 236.1303 5602877 123.7484 302.5767  0.00001000 67521"
+	)
+
+	_, err := NewTLE(line1, line2, WithChecksum(ChecksumIgnore))
+	var ie *InitError
+	if !errors.As(err, &ie) || ie.CatNum != 33334 || !errors.Is(err, ErrPerturbedEccentricity) {
+		t.Fatal(err)
+	}
+
+	t.Run("Set", func(t *testing.T) {
+		o := getExample(t)
+		t0 := o.Epoch()
+
+		err := o.Set(time.Time{}, 0, 0, 0, 0, 0, 0.9999, 0, 0, 0, 0)
+		if !errors.As(err, &ie) {
+			t.Fatal(err)
+		}
+		// The TLE is unusable.
+		var pe *PropError
+		if _, err := o.Prop(t0); !errors.As(err, &pe) || pe.Code != ie.Code {
+			t.Fatal(err)
+		}
+
+		if err := o.Set(time.Time{}, 0, 0, 0, 0, 0, 0.0001731, 0, 0, 0, 0); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := o.Prop(t0); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("OMM", func(t *testing.T) {
+		o, err := ParseOMMKVN(strings.NewReader(issKVN))
+		if err != nil {
+			t.Fatal(err)
+		}
+		o.MeanMotion = 0.00001
+		if _, err := NewTLEFromOMM(o); !errors.As(err, &ie) {
+			t.Fatal(err)
+		}
+	})
+}
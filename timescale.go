@@ -0,0 +1,279 @@
+package sgp4go
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JulianDate is a two-part Julian date, which keeps about a
+// nanosecond of resolution.
+//
+// Day is a whole number of days plus one half (so that the date
+// starts at 0h), and Frac is the fraction of the day in [0,1).
+type JulianDate struct {
+	Day, Frac float64
+}
+
+// newJulianDate normalizes the given parts.
+func newJulianDate(day, frac float64) JulianDate {
+	d := math.Floor(frac)
+	return JulianDate{day + d, frac - d}
+}
+
+// JD returns the Julian date as a single number.
+func (jd JulianDate) JD() float64 {
+	return jd.Day + jd.Frac
+}
+
+// MJD returns the modified Julian date.
+func (jd JulianDate) MJD() float64 {
+	return (jd.Day - 2400000.5) + jd.Frac
+}
+
+// Centuries returns the Julian centuries since J2000.
+func (jd JulianDate) Centuries() float64 {
+	return ((jd.Day - 2451545.0) + jd.Frac) / 36525
+}
+
+// AddSeconds returns the Julian date plus the given seconds.
+func (jd JulianDate) AddSeconds(secs float64) JulianDate {
+	return newJulianDate(jd.Day, jd.Frac+secs/86400)
+}
+
+// Scale is a time scale.
+type Scale int
+
+const (
+	// UTC is Coordinated Universal Time.
+	UTC Scale = iota
+
+	// TAI is International Atomic Time.
+	TAI
+
+	// TT is Terrestrial Time.
+	TT
+
+	// GPS is GPS time.
+	GPS
+
+	// UT1 is Universal Time, which follows the Earth's rotation.
+	UT1
+
+	// TDB is Barycentric Dynamical Time.
+	TDB
+)
+
+func (s Scale) String() string {
+	switch s {
+	case UTC:
+		return "UTC"
+	case TAI:
+		return "TAI"
+	case TT:
+		return "TT"
+	case GPS:
+		return "GPS"
+	case UT1:
+		return "UT1"
+	case TDB:
+		return "TDB"
+	}
+	return fmt.Sprintf("Scale(%d)", int(s))
+}
+
+// LeapSeconds is a table of TAI-UTC.
+type LeapSeconds struct {
+	// starts are the (Unix) times when the offsets start.
+	starts  []int64
+	offsets []float64
+}
+
+// DefaultLeapSeconds is the table of leap seconds through the one at
+// the end of 2016.
+var DefaultLeapSeconds = func() *LeapSeconds {
+	l := &LeapSeconds{}
+	for i, d := range []string{
+		"1972-01-01", "1972-07-01", "1973-01-01", "1974-01-01",
+		"1975-01-01", "1976-01-01", "1977-01-01", "1978-01-01",
+		"1979-01-01", "1980-01-01", "1981-07-01", "1982-07-01",
+		"1983-07-01", "1985-07-01", "1988-01-01", "1990-01-01",
+		"1991-01-01", "1992-07-01", "1993-07-01", "1994-07-01",
+		"1996-01-01", "1997-07-01", "1999-01-01", "2006-01-01",
+		"2009-01-01", "2012-07-01", "2015-07-01", "2017-01-01",
+	} {
+		t, err := time.Parse("2006-01-02", d)
+		if err != nil {
+			panic(err)
+		}
+		l.starts = append(l.starts, t.Unix())
+		l.offsets = append(l.offsets, float64(10+i))
+	}
+	return l
+}()
+
+// ParseLeapSecondDat parses IERS Leap_Second.dat data, which has
+// lines like
+//
+//	41317.0    1  1 1972       10
+//
+// (MJD, day, month, year, and TAI-UTC) and comments starting with '#'.
+func ParseLeapSecondDat(r io.Reader) (*LeapSeconds, error) {
+	var (
+		l = &LeapSeconds{}
+		s = bufio.NewScanner(r)
+		n int
+	)
+	for s.Scan() {
+		n++
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fs := strings.Fields(line)
+		if len(fs) != 5 {
+			return nil, fmt.Errorf("Leap_Second.dat line %d: %d fields", n, len(fs))
+		}
+		mjd, err := strconv.ParseFloat(fs[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("Leap_Second.dat line %d: %w", n, err)
+		}
+		off, err := strconv.ParseFloat(fs[4], 64)
+		if err != nil {
+			return nil, fmt.Errorf("Leap_Second.dat line %d: %w", n, err)
+		}
+		start := int64(math.Round((mjd - 40587) * 86400))
+		if k := len(l.starts); 0 < k && start <= l.starts[k-1] {
+			return nil, fmt.Errorf("Leap_Second.dat line %d: MJD %v out of order", n, mjd)
+		}
+		l.starts = append(l.starts, start)
+		l.offsets = append(l.offsets, off)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if len(l.starts) == 0 {
+		return nil, fmt.Errorf("no leap seconds in Leap_Second.dat")
+	}
+	return l, nil
+}
+
+// TAIMinusUTC returns TAI-UTC in seconds at the given time.
+//
+// Before 1972, when UTC wasn't kept in whole seconds from TAI, the
+// result is the 1972 value (10 seconds).  A nil *LeapSeconds is
+// DefaultLeapSeconds.
+func (l *LeapSeconds) TAIMinusUTC(t time.Time) float64 {
+	if l == nil {
+		l = DefaultLeapSeconds
+	}
+	secs := t.Unix()
+	i := sort.Search(len(l.starts), func(i int) bool {
+		return secs < l.starts[i]
+	})
+	if i == 0 {
+		return l.offsets[0]
+	}
+	return l.offsets[i-1]
+}
+
+const (
+	// ttMinusTAI is TT-TAI in seconds.
+	ttMinusTAI = 32.184
+
+	// taiMinusGPS is TAI-GPS in seconds.
+	taiMinusGPS = 19
+)
+
+// TimeScales converts UTC times to other time scales.
+//
+// The zero value uses DefaultLeapSeconds and takes UT1 to be UTC.
+// The frame conversions take their leap seconds from EOP.Leaps
+// instead.
+type TimeScales struct {
+	// Leaps is the table of leap seconds (DefaultLeapSeconds if nil).
+	Leaps *LeapSeconds
+
+	// EOP provides UT1-UTC (which is zero if nil).
+	EOP *EOPTable
+}
+
+// Offset returns the given scale minus UTC in seconds at the given
+// time.
+//
+// TDB uses the approximation (good to tens of microseconds) from the
+// Astronomical Almanac.
+func (ts *TimeScales) Offset(t time.Time, s Scale) float64 {
+	var (
+		tai = ts.Leaps.TAIMinusUTC(t)
+		tt  = tai + ttMinusTAI
+	)
+	switch s {
+	case TAI:
+		return tai
+	case TT:
+		return tt
+	case GPS:
+		return tai - taiMinusGPS
+	case UT1:
+		eop, _ := ts.EOP.At(t)
+		return eop.UT1MinusUTC
+	case TDB:
+		var (
+			jd = toJulianDate(t).AddSeconds(tt)
+			g  = (357.53 + 0.98560028*((jd.Day-2451545.0)+jd.Frac)) * math.Pi / 180
+		)
+		return tt + 0.001657*math.Sin(g) + 0.000014*math.Sin(2*g)
+	}
+	return 0
+}
+
+// JulianDate returns the Julian date of the given time in the given
+// scale.
+func (ts *TimeScales) JulianDate(t time.Time, s Scale) JulianDate {
+	return toJulianDate(t).AddSeconds(ts.Offset(t, s))
+}
+
+// Time converts a Julian date in the given scale to a (UTC)
+// time.Time.
+func (ts *TimeScales) Time(jd JulianDate, s Scale) time.Time {
+	t := fromJulianDate(jd)
+	// The offset depends (slightly) on the UTC time.
+	for i := 0; i < 3; i++ {
+		t = fromJulianDate(jd.AddSeconds(-ts.Offset(t, s)))
+	}
+	return t
+}
+
+// toJulianDate returns the UTC Julian date of the given time.
+//
+// The whole days come from the Unix time's days, and the fraction
+// from its seconds and nanoseconds, so no precision is lost.  This is
+// the only conversion from a time.Time to a Julian date.
+func toJulianDate(t time.Time) JulianDate {
+	var (
+		secs = t.Unix()
+		days = secs / 86400
+	)
+	if secs%86400 < 0 {
+		days--
+	}
+	frac := (float64(secs-days*86400) + float64(t.Nanosecond())/1e9) / 86400
+	return JulianDate{2440587.5 + float64(days), frac}
+}
+
+// fromJulianDate converts a Julian date to a time.Time without
+// considering time scales.
+func fromJulianDate(jd JulianDate) time.Time {
+	var (
+		days = math.Floor(jd.Day - 2440587.5)
+		frac = (jd.Day - 2440587.5 - days) + jd.Frac
+	)
+	return time.Unix(int64(days)*86400, 0).UTC().
+		Add(time.Duration(math.Round(frac * 86400e9)))
+}
@@ -0,0 +1,124 @@
+package sgp4go
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+var leapSecondDat = `#  Value of TAI-UTC in second valid beetween the initial value until
+#  the epoch given on the next line. The last line reads that NO
+#  leap second was introduced since the corresponding date
+#
+#
+#    MJD        Date        TAI-UTC (s)
+#           day month year
+#    ---    --------------   ------
+#
+    41317.0    1  1 1972       10
+    41499.0    1  7 1972       11
+    41683.0    1  1 1973       12
+    57204.0    1  7 2015       36
+    57754.0    1  1 2017       37
+`
+
+func TestLeapSeconds(t *testing.T) {
+	var (
+		leap = time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+		ts   TimeScales
+	)
+
+	for _, tc := range []struct {
+		t   time.Time
+		off float64
+	}{
+		{time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC), 10},
+		{time.Date(1972, 1, 1, 0, 0, 0, 0, time.UTC), 10},
+		{time.Date(1972, 7, 1, 0, 0, 0, 0, time.UTC), 11},
+		{time.Date(2004, 4, 6, 0, 0, 0, 0, time.UTC), 32},
+		{leap.Add(-time.Nanosecond), 36},
+		{leap, 37},
+		{time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), 37},
+	} {
+		if off := DefaultLeapSeconds.TAIMinusUTC(tc.t); off != tc.off {
+			t.Fatal(tc.t, off)
+		}
+		if off := ts.Offset(tc.t, TAI); off != tc.off {
+			t.Fatal(tc.t, off)
+		}
+	}
+
+	l, err := ParseLeapSecondDat(strings.NewReader(leapSecondDat))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The sample only has some of the leap seconds.
+	for _, at := range []time.Time{leap.Add(-time.Second), leap, time.Date(1972, 12, 31, 0, 0, 0, 0, time.UTC)} {
+		if got, want := l.TAIMinusUTC(at), DefaultLeapSeconds.TAIMinusUTC(at); got != want {
+			t.Fatal(at, got, want)
+		}
+	}
+
+	if _, err := ParseLeapSecondDat(strings.NewReader("41317.0 1 1 1972\n")); err == nil {
+		t.Fatal("no error")
+	}
+}
+
+func TestTimeScales(t *testing.T) {
+	var (
+		at = vallado0406.t
+		ts TimeScales
+	)
+
+	for _, tc := range []struct {
+		s   Scale
+		off float64
+	}{
+		{UTC, 0},
+		{TAI, 32},
+		{TT, 64.184},
+		{GPS, 13},
+		{UT1, 0},
+	} {
+		if off := ts.Offset(at, tc.s); off != tc.off {
+			t.Fatal(tc.s, off)
+		}
+	}
+
+	// TDB differs from TT by less than 2 milliseconds.
+	if d := ts.Offset(at, TDB) - ts.Offset(at, TT); 0.002 < math.Abs(d) {
+		t.Fatal(d)
+	}
+
+	// Vallado's Example 3-15 gives these centuries (TT).
+	if ttt := ts.JulianDate(at, TT).Centuries(); 1e-10 < math.Abs(ttt-0.0426236319) {
+		t.Fatal(ttt)
+	}
+
+	jd := ts.JulianDate(at, UTC)
+	if jd.Day != 2453101.5 || 1e-9 < math.Abs(jd.MJD()-53101.3274118751) {
+		t.Fatal(jd)
+	}
+
+	for _, s := range []Scale{UTC, TAI, TT, GPS, TDB} {
+		got := ts.Time(ts.JulianDate(at, s), s)
+		if d := got.Sub(at); d < -time.Microsecond || time.Microsecond < d {
+			t.Fatal(s, got)
+		}
+	}
+
+	t.Run("UT1", func(t *testing.T) {
+		tab, err := ParseFinalsAll(strings.NewReader(finalsAll))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var (
+			ts  = TimeScales{EOP: tab}
+			day = time.Date(2016, 12, 30, 0, 0, 0, 0, time.UTC)
+		)
+		if off := ts.Offset(day, UT1); off != -0.4081234 {
+			t.Fatal(off)
+		}
+	})
+}
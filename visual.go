@@ -0,0 +1,105 @@
+package sgp4go
+
+import (
+	"math"
+	"time"
+)
+
+// The Sun's elevations (degrees) at the ends of twilight.
+const (
+	CivilTwilight        = -6.0
+	NauticalTwilight     = -12.0
+	AstronomicalTwilight = -18.0
+)
+
+// VisualPass is an interval when a satellite is visible to an
+// Observer.
+type VisualPass struct {
+	// AOS and LOS are when the satellite becomes visible and stops
+	// being visible, and TCA is its greatest elevation in between.
+	Pass
+
+	// Magnitude is the satellite's estimated apparent magnitude at
+	// the TCA.  See Observer.Magnitude().
+	Magnitude float64
+}
+
+// VisualPassFinder finds passes when a satellite is visible: it's
+// above the Mask, it's sunlit (at least in part), and the Sun is
+// below the Twilight elevation for the Observer.
+type VisualPassFinder struct {
+	PassFinder
+
+	// Twilight is the Sun's greatest elevation (degrees) for
+	// observing, such as CivilTwilight.  The zero value is sunset.
+	Twilight float64
+
+	// StdMag is the satellite's standard magnitude.  See
+	// Observer.Magnitude().
+	StdMag float64
+}
+
+// VisualPasses returns the visual passes of the TLE from start to
+// stop.
+//
+// A pass can have more than one visual pass, as when the satellite
+// enters the Earth's shadow and the TCA is before that.  Errors are as
+// for PassFinder.Passes().
+func (f *VisualPassFinder) VisualPasses(tle *TLE, start, stop time.Time) ([]VisualPass, error) {
+	passes, err := f.Passes(tle, start, stop)
+
+	// invisible is negative when the satellite is visible.
+	invisible := func(t time.Time) (float64, error) {
+		s, err := f.sample(tle, t)
+		if err != nil {
+			return 0, err
+		}
+		var e Ephemeris
+		if err := tle.PropInto(t, &e); err != nil {
+			return 0, err
+		}
+		a, b, c := shadowAngles(e.ECI, SunPosition(t))
+		return math.Max(math.Max(
+			-s.above,
+			f.Observer.SunElevation(t)-f.Twilight),
+			(b-a-c)*180/math.Pi), nil
+	}
+
+	var (
+		tol     = f.tolerance()
+		visuals []VisualPass
+	)
+	for _, p := range passes {
+		iv := Interval{p.AOS.Time, p.LOS.Time}
+		ivals, err := searchFunc(invisible).negative(iv.Start, iv.Stop, innerStep(iv, f.step(), tol), tol)
+		if err != nil {
+			return visuals, err
+		}
+		for _, iv := range ivals {
+			v, err := f.visualPass(tle, iv)
+			if err != nil {
+				return visuals, err
+			}
+			visuals = append(visuals, v)
+		}
+	}
+	return visuals, err
+}
+
+// visualPass returns the visual pass for the given interval.
+func (f *VisualPassFinder) visualPass(tle *TLE, iv Interval) (VisualPass, error) {
+	p, err := f.pass(tle, iv)
+	if err != nil {
+		return VisualPass{}, err
+	}
+
+	var e Ephemeris
+	if err := tle.PropInto(p.TCA.Time, &e); err != nil {
+		return VisualPass{}, err
+	}
+
+	return VisualPass{
+		Pass:      p,
+		Magnitude: f.Observer.Magnitude(p.TCA.Time, e, f.StdMag),
+	}, nil
+}
@@ -0,0 +1,118 @@
+package sgp4go
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestMagnitude(t *testing.T) {
+	if m := magnitude(-1.8, 1000, math.Pi/2); 1e-12 < math.Abs(m+1.8) {
+		t.Fatal(m)
+	}
+	// Ten times farther is five magnitudes dimmer.
+	if m := magnitude(-1.8, 10000, math.Pi/2); 1e-12 < math.Abs(m-3.2) {
+		t.Fatal(m)
+	}
+	// Fully illuminated is brighter.
+	if m := magnitude(-1.8, 1000, 0); 1e-12 < math.Abs(m+1.8+2.5*math.Log10(math.Pi)) {
+		t.Fatal(m)
+	}
+}
+
+func TestVisualPasses(t *testing.T) {
+	var (
+		tle   = getExample(t)
+		o     = &Observer{Position: LatLonAlt{Lat: 38.9, Lon: -77.0, Alt: 0.1}}
+		start = time.Date(2020, 12, 19, 0, 0, 0, 0, time.UTC)
+		stop  = start.Add(5 * 24 * time.Hour)
+		f     = &VisualPassFinder{
+			PassFinder: PassFinder{Observer: o, Mask: Mask{Min: 5}},
+			Twilight:   NauticalTwilight,
+			StdMag:     -1.8,
+		}
+	)
+
+	visible := func(at time.Time) bool {
+		l, err := o.LookAt(tle, at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		i, err := tle.Illumination(at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return 5 <= l.El && 0 < i && o.SunElevation(at) <= NauticalTwilight
+	}
+
+	// Count the visual passes by sampling every second during the
+	// passes.
+	passes, err := f.Passes(tle, start, stop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var n int
+	for _, p := range passes {
+		in := false
+		for at := p.AOS.Time; !p.LOS.Time.Before(at); at = at.Add(time.Second) {
+			v := visible(at)
+			if v && !in {
+				n++
+			}
+			in = v
+		}
+	}
+
+	vs, err := f.VisualPasses(tle, start, stop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vs) != n || n < 3 {
+		t.Fatal(len(vs), n)
+	}
+
+	var shadowed int
+	for i, v := range vs {
+		if v.TCA.Time.Before(v.AOS.Time) || v.LOS.Time.Before(v.TCA.Time) {
+			t.Fatal(i, v)
+		}
+		// The times are good to a few milliseconds.
+		for _, tc := range []struct {
+			in, out time.Time
+		}{
+			{v.AOS.Time, v.AOS.Time.Add(-5 * time.Millisecond)},
+			{v.LOS.Time, v.LOS.Time.Add(5 * time.Millisecond)},
+		} {
+			if !visible(tc.in) || visible(tc.out) {
+				t.Fatal(i, v, tc.in)
+			}
+		}
+
+		ill, err := tle.Illumination(v.TCA.Time)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ill < 1 {
+			// The TCA is when the satellite enters or leaves the
+			// shadow, where it's dim.
+			shadowed++
+			if 10*time.Millisecond < v.LOS.Time.Sub(v.TCA.Time) && 10*time.Millisecond < v.TCA.Time.Sub(v.AOS.Time) || v.Magnitude < 5 {
+				t.Fatal(i, v)
+			}
+			continue
+		}
+		// The ISS is bright.
+		if v.Magnitude < -5 || 2 < v.Magnitude {
+			t.Fatal(i, v)
+		}
+	}
+	if shadowed == 0 || shadowed == len(vs) {
+		t.Fatal(shadowed)
+	}
+
+	// In broad daylight, nothing is visible.
+	f.Twilight = -90
+	if vs, err := f.VisualPasses(tle, start, stop); err != nil || len(vs) != 0 {
+		t.Fatal(vs, err)
+	}
+}